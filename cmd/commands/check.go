@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/bridge"
+	"github.com/spf13/cobra"
+)
+
+var checkFlags struct {
+	outPath string
+}
+
+// CheckCmd verifies that the committed *.cgen.yml and MX_Device.h files match the
+// generator output, e.g. that the code was regenerated after changing the .ioc file
+var CheckCmd = &cobra.Command{
+	Use:   "check <file>.cbuild-gen-idx.yml",
+	Short: "Check that the generated files are up to date",
+	Long: "Regenerates the *.cgen.yml and MX_Device.h files into a temporary folder and compares them " +
+		"to the files on disk. Reports the differences and exits with an error if they are out of date.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := bridge.Check(args[0], checkFlags.outPath, getGeneratorOptions(cmd))
+		if err != nil {
+			return err
+		}
+		if report != "" {
+			fmt.Fprint(cmd.OutOrStdout(), report)
+			return errors.New("generated files are out of date, regenerate the code and commit the result")
+		}
+		return nil
+	},
+}
+
+func init() {
+	CheckCmd.Flags().StringVarP(&checkFlags.outPath, "out", "o", "", "Output path for generated files")
+	addGeneratorOptions(CheckCmd)
+	AllCommands = append(AllCommands, CheckCmd)
+}
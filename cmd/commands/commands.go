@@ -11,15 +11,28 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/open-cmsis-pack/generator-bridge/internal/bridge"
+	_ "github.com/open-cmsis-pack/generator-bridge/internal/infineon"   // registers the Infineon Device Configurator backend
+	_ "github.com/open-cmsis-pack/generator-bridge/internal/mcuxpresso" // registers the MCUXpresso Config Tools backend
 	readfile "github.com/open-cmsis-pack/generator-bridge/internal/readFile"
 	stm32cubemx "github.com/open-cmsis-pack/generator-bridge/internal/stm32CubeMX"
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
+// The daemon logs to daemon.log in the user cache directory if no log file is given
+const (
+	daemonLogDir     = "generator-bridge"
+	daemonLogName    = "daemon.log"
+	daemonLogKeep    = 3
+	daemonLogMaxSize = 4 << 20
+)
+
 // AllCommands contains all available commands for generator-bridge
 var AllCommands = []*cobra.Command{}
 
@@ -33,24 +46,24 @@ func GetConfig() *pflag.FlagSet {
 func configureGlobalCmd(cmd *cobra.Command, args []string) error {
 	log.SetLevel(log.InfoLevel)
 	pid, _ := GetConfig().GetInt("process")
-	if flags.logFile == "" {
-		if pid == -1 { // not the daemon
-			log.SetOutput(os.Stdout)
-		} else { // I am the daemon
-			log.SetOutput(io.Discard)
-		}
+	if flags.launchFile != "" {
+		pid = 0 // the daemon starting the generator
+	}
+	daemon := pid != -1
+	var f *os.File
+	var err error
+	if flags.logFile != "" {
+		f, err = os.OpenFile(flags.logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	} else if daemon {
+		f, err = openDefaultDaemonLog()
+	}
+	if err == nil && f != nil {
+		stm32cubemx.LogFile = f
+		log.SetOutput(f)
+	} else if daemon { // could not create or open the log file
+		log.SetOutput(io.Discard)
 	} else {
-		f, err := os.OpenFile(flags.logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
-		if err != nil || f == nil { // could not create or open the log file
-			if pid == -1 { // not the daemon
-				log.SetOutput(os.Stdout)
-			} else { // I am the daemon
-				log.SetOutput(io.Discard)
-			}
-		} else {
-			stm32cubemx.LogFile = f
-			log.SetOutput(f)
-		}
+		log.SetOutput(os.Stdout)
 	}
 
 	verbosiness, _ := GetConfig().GetBool("verbose")
@@ -70,14 +83,42 @@ func configureGlobalCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// DefaultDaemonLog returns the log file of a daemon started without "-l"
+func DefaultDaemonLog() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, daemonLogDir, daemonLogName), nil
+}
+
+// openDefaultDaemonLog appends to the default daemon log, which is rotated when it
+// grows too large
+func openDefaultDaemonLog() (*os.File, error) {
+	path, err := DefaultDaemonLog()
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > daemonLogMaxSize {
+		if err = utils.RotateFile(path, daemonLogKeep); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+}
+
 var flags struct {
-	version bool
-	help    bool
-	daemon  bool
-	inFile  string
-	inFile2 string
-	outPath string
-	logFile string
+	version    bool
+	help       bool
+	daemon     bool
+	inFile     string
+	inFile2    string
+	outPath    string
+	logFile    string
+	launchFile string
 }
 
 var Version string
@@ -112,6 +153,35 @@ Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
 Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
 `
 
+// generatorOptions are the command line options passed to the generator backend
+var generatorOptions = []struct {
+	name  string
+	value string
+	usage string
+}{
+	{stm32cubemx.OptionMXDevice, string(stm32cubemx.MXDeviceFromIoc), "CubeMX: source of the MX_Device.h values: 'ioc' (sources only for missing values) or 'sources'"},
+	{stm32cubemx.OptionLayout, string(stm32cubemx.LayoutIDE), "CubeMX: project layout: 'ide' (MDK-ARM, EWARM, STM32CubeIDE) or 'cmake' (GCC and CLANG only)"},
+	{stm32cubemx.OptionMiddleware, string(stm32cubemx.MiddlewareFromCubeMX), "CubeMX: source of the middlewares enabled in CubeMX: 'cubemx' (copied sources) or 'packs' (CMSIS packs and components)"},
+}
+
+// addGeneratorOptions registers the generator options on a command running a backend
+func addGeneratorOptions(cmd *cobra.Command) {
+	for _, option := range generatorOptions {
+		cmd.Flags().String(option.name, option.value, option.usage)
+	}
+}
+
+// getGeneratorOptions returns the generator options given on the command line
+func getGeneratorOptions(cmd *cobra.Command) map[string]string {
+	options := make(map[string]string)
+	for _, option := range generatorOptions {
+		if cmd.Flags().Changed(option.name) {
+			options[option.name], _ = cmd.Flags().GetString(option.name)
+		}
+	}
+	return options
+}
+
 func NewCli() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:               "generator-bridge [command] [flags]",
@@ -120,6 +190,7 @@ func NewCli() *cobra.Command {
 		SilenceUsage:      true,
 		SilenceErrors:     true,
 		PersistentPreRunE: configureGlobalCmd,
+		Args:              cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Debugln("Command line:", args)
 			if flags.version {
@@ -135,10 +206,14 @@ func NewCli() *cobra.Command {
 				return readfile.Process(flags.inFile, flags.inFile2, flags.outPath)
 			}
 
+			if len(args) == 1 && flags.launchFile != "" {
+				return bridge.Supervise(args[0], flags.outPath, flags.launchFile, getGeneratorOptions(cmd))
+			}
+
 			if len(args) == 1 {
 				cbuildYmlPath := args[0]
 				pid, _ := GetConfig().GetInt("process")
-				return stm32cubemx.Process(cbuildYmlPath, flags.outPath, "", pid == -1, pid)
+				return bridge.Process(cbuildYmlPath, flags.outPath, pid == -1, pid, getGeneratorOptions(cmd))
 			}
 
 			return cmd.Help()
@@ -152,7 +227,10 @@ func NewCli() *cobra.Command {
 	rootCmd.Flags().StringVarP(&flags.inFile, "read", "r", "", "Reads an input file, type is auto determined")
 	rootCmd.Flags().StringVarP(&flags.inFile2, "file", "f", "", "Additional input file, type is auto determined")
 	rootCmd.Flags().StringVarP(&flags.outPath, "out", "o", "", "Output path for generated files")
-	rootCmd.Flags().StringVarP(&flags.logFile, "log", "l", "", "Log file")
+	rootCmd.Flags().StringVarP(&flags.logFile, "log", "l", "", "Log file, a daemon logs to daemon.log in the user cache directory by default")
+	rootCmd.Flags().StringVar(&flags.launchFile, "launch", "", "Daemon: start the generator with this file and supervise it")
+	_ = rootCmd.Flags().MarkHidden("launch")
+	addGeneratorOptions(rootCmd)
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Run silently, printing only error messages")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Sets verboseness level: None (Errors + Info + Warnings), -v (all + Debugging). Specify \"-q\" for no messages")
 	rootCmd.PersistentFlags().BoolP("daemon", "D", false, "run as a daemon, never exit")
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/bridge"
+	"github.com/spf13/cobra"
+)
+
+// DaemonCmd groups the commands driving the bridge daemon of a solution
+var DaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Query and control a running bridge daemon",
+	Long: "The bridge daemon watches the generator launched for a solution. These commands talk to it " +
+		"over its control socket, which is identified by the <file>.cbuild-gen-idx.yml of the solution.",
+}
+
+func newDaemonCmd(command, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   command + " <file>.cbuild-gen-idx.yml",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			response, err := bridge.SendDaemonCommand(args[0], command)
+			if err != nil {
+				return err
+			}
+			if command == bridge.DaemonStatus && response.Status != nil {
+				printDaemonState(cmd.OutOrStdout(), response.Status)
+			}
+			return nil
+		},
+	}
+}
+
+func printDaemonState(out io.Writer, state *bridge.DaemonState) {
+	fmt.Fprintf(out, "pid: %v\n", state.PID)
+	fmt.Fprintf(out, "generator: %v\n", state.Generator)
+	fmt.Fprintf(out, "generator-pid: %v\n", state.GeneratorPID)
+	fmt.Fprintf(out, "cbuild-gen-idx: %v\n", state.CbuildGenIdx)
+	fmt.Fprintf(out, "work-dir: %v\n", state.WorkDir)
+	fmt.Fprintf(out, "started: %v\n", state.Started.Format(time.RFC3339))
+	if !state.LastGenerated.IsZero() {
+		fmt.Fprintf(out, "last-generated: %v\n", state.LastGenerated.Format(time.RFC3339))
+	}
+	if state.LastError != "" {
+		fmt.Fprintf(out, "last-error: %v\n", state.LastError)
+	}
+}
+
+func init() {
+	DaemonCmd.AddCommand(
+		newDaemonCmd(bridge.DaemonStatus, "Print the state of the daemon"),
+		newDaemonCmd(bridge.DaemonRegenerate, "Regenerate the cgen.yml files from the generator output"),
+		newDaemonCmd(bridge.DaemonReload, "Read global.generator.yml and the cbuild-gen-idx.yml file again"),
+		newDaemonCmd(bridge.DaemonStop, "Stop the daemon, the generator keeps running"),
+	)
+	AllCommands = append(AllCommands, DaemonCmd)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/bridge"
+	"github.com/spf13/cobra"
+)
+
+var generateFlags struct {
+	outPath  string
+	headless bool
+}
+
+// GenerateCmd generates the code with the vendor tool and writes the *.cgen.yml files
+// without user interaction, e.g. in CI jobs or cbuild runs on build servers
+var GenerateCmd = &cobra.Command{
+	Use:   "generate <file>.cbuild-gen-idx.yml --headless",
+	Short: "Generate code with the generator in script mode",
+	Long: "Runs the generator without user interface to generate the code of the project, waits for it " +
+		"to end and writes the *.cgen.yml files of all contexts. A new project is created for the device " +
+		"or board. The generator output is written to a session log in the output directory.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !generateFlags.headless {
+			return errors.New("only headless code generation is supported, specify --headless or " +
+				"run generator-bridge <file>.cbuild-gen-idx.yml to open the generator")
+		}
+		return bridge.Generate(args[0], generateFlags.outPath, getGeneratorOptions(cmd))
+	},
+}
+
+func init() {
+	GenerateCmd.Flags().StringVarP(&generateFlags.outPath, "out", "o", "", "Output path for generated files")
+	GenerateCmd.Flags().BoolVar(&generateFlags.headless, "headless", false, "Run the generator without user interface")
+	addGeneratorOptions(GenerateCmd)
+	AllCommands = append(AllCommands, GenerateCmd)
+}
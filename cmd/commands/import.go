@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"github.com/open-cmsis-pack/generator-bridge/internal/bridge"
+	"github.com/spf13/cobra"
+)
+
+var importFlags struct {
+	outPath string
+}
+
+// ImportCmd regenerates the *.cgen.yml and MX_Device.h files from the committed
+// vendor tool output without launching the vendor tool
+var ImportCmd = &cobra.Command{
+	Use:   "import <file>.cbuild-gen-idx.yml",
+	Short: "Regenerate cgen.yml files from existing generator output",
+	Long: "Reads the generator output (e.g. STM32CubeMX.ioc, .mxproject and the generated sources) " +
+		"and writes the *.cgen.yml files of all contexts. The vendor tool is not required.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return bridge.Import(args[0], importFlags.outPath, getGeneratorOptions(cmd))
+	},
+}
+
+func init() {
+	ImportCmd.Flags().StringVarP(&importFlags.outPath, "out", "o", "", "Output path for generated files")
+	addGeneratorOptions(ImportCmd)
+	AllCommands = append(AllCommands, ImportCmd)
+}
@@ -0,0 +1,467 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// Process handles setup, launch, and daemon monitoring for the generator
+// backend selected by the generator id in the cbuild-gen-idx.yml file.
+// The options given on the command line are passed to the backend.
+func Process(cbuildGenIdxYmlPath, outPath string, runGenerator bool, pid int, options map[string]string) error {
+	generatorFile, err := FindGeneratorFile()
+	if err != nil {
+		return err
+	}
+
+	gen, err := SelectGenerator(cbuildGenIdxYmlPath)
+	if err != nil {
+		return err
+	}
+	if err = configureOptions(gen, options); err != nil {
+		return err
+	}
+
+	gParms, err := configureGenerator(gen, generatorFile)
+	if err != nil {
+		return err
+	}
+
+	workDir, err := ReadBridgeParams(gen, cbuildGenIdxYmlPath, outPath)
+	if err != nil {
+		return err
+	}
+
+	if pid >= 0 { // attach to the running generator
+		return runDaemon(gen, cbuildGenIdxYmlPath, outPath, workDir,
+			func() (int, error) { return pid, nil },
+			func() error { return gen.Watch(workDir, pid) })
+	}
+
+	if runGenerator {
+		lock, holder, err := acquireLock(workDir, cbuildGenIdxYmlPath)
+		if err != nil {
+			return err
+		}
+		if lock == nil {
+			return handOff(cbuildGenIdxYmlPath, holder)
+		}
+
+		daemon, err := launchGenerator(gen, gParms, workDir)
+		if err != nil || daemon == 0 {
+			lock.release()
+			return err
+		}
+		return lock.handOver(daemon)
+	}
+	return nil
+}
+
+// Supervise is the daemon started by Process for backends implementing generator.Supervisor.
+// It starts the generator with the launch file and watches it until it exits.
+func Supervise(cbuildGenIdxYmlPath, outPath, launchFile string, options map[string]string) error {
+	generatorFile, err := FindGeneratorFile()
+	if err != nil {
+		return err
+	}
+
+	gen, err := SelectGenerator(cbuildGenIdxYmlPath)
+	if err != nil {
+		return err
+	}
+	supervisor, ok := gen.(generator.Supervisor)
+	if !ok {
+		return errors.New("generator '" + gen.ID() + "' cannot be supervised")
+	}
+	if err = configureOptions(gen, options); err != nil {
+		return err
+	}
+
+	if _, err = configureGenerator(gen, generatorFile); err != nil {
+		return err
+	}
+
+	workDir, err := ReadBridgeParams(gen, cbuildGenIdxYmlPath, outPath)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := supervisor.Command(workDir, launchFile)
+	if err != nil {
+		return err
+	}
+	return runDaemon(gen, cbuildGenIdxYmlPath, outPath, workDir,
+		func() (int, error) {
+			log.Debugf("Start generator as %v", cmd)
+			if err := cmd.Start(); err != nil {
+				return -1, err
+			}
+			return cmd.Process.Pid, nil
+		},
+		func() error { return supervisor.Supervise(workDir, cmd) })
+}
+
+// runDaemon takes over the lock of the work dir, starts or attaches to the generator
+// and serves the control channel while the generator is watched
+func runDaemon(gen generator.Generator, cbuildGenIdxYmlPath, outPath, workDir string, start func() (int, error), watch func() error) error {
+	lock, holder, err := acquireLock(workDir, cbuildGenIdxYmlPath)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return fmt.Errorf("bridge daemon already running for %v with pid %d", workDir, holder)
+	}
+	defer lock.release()
+	log.Infof("Bridge daemon %d started %v for %v", os.Getpid(), time.Now().Format(time.DateTime), cbuildGenIdxYmlPath)
+
+	pid, err := start()
+	if err != nil {
+		return err
+	}
+
+	server, err := startControlServer(gen, cbuildGenIdxYmlPath, outPath, workDir, pid)
+	if err != nil {
+		log.Warnf("daemon control channel not available: %v", err)
+		lock.keepAlive()
+	} else {
+		defer server.Close()
+	}
+	return watch()
+}
+
+// launchGenerator starts the generator and the daemon watching it and returns the pid
+// of the daemon. A generator that already finished has written its output, the
+// *.cgen.yml files are updated and no daemon is started, the pid is 0.
+func launchGenerator(gen generator.Generator, gParms generator.ParamsType, workDir string) (int, error) {
+	launchFile, err := gen.WriteLaunchProject(workDir)
+	if err != nil {
+		return 0, err
+	}
+
+	if supervisor, ok := gen.(generator.Supervisor); ok {
+		// the daemon starts the generator, the command is created here to report its errors
+		if _, err = supervisor.Command(workDir, launchFile); err != nil {
+			return 0, launchError(gParms, err)
+		}
+		return startDaemon("--launch", launchFile)
+	}
+
+	pid, err := gen.Launch(workDir, launchFile)
+	if err != nil {
+		return 0, launchError(gParms, err)
+	}
+	if pid < 0 { // generator already finished
+		return 0, gen.WriteCgen(workDir)
+	}
+	// here the generator runs
+	return startDaemon("-p", fmt.Sprint(pid))
+}
+
+func launchError(gParms generator.ParamsType, err error) error {
+	if gParms.DownloadURL == "" {
+		return fmt.Errorf("failed to launch generator '%s': %w", gParms.ID, err)
+	}
+	return fmt.Errorf("failed to launch generator '%s': %w. If not installed, get it from '%s'", gParms.ID, err, gParms.DownloadURL)
+}
+
+// handOff lets the bridge already serving the work dir regenerate the files
+// instead of launching a second generator
+func handOff(cbuildGenIdxYmlPath string, holder int) error {
+	log.Infof("Bridge already running with pid %d, regenerating the files there", holder)
+	if _, err := SendDaemonCommand(cbuildGenIdxYmlPath, DaemonRegenerate); err != nil {
+		return fmt.Errorf("bridge already running with pid %d: %w", holder, err)
+	}
+	return nil
+}
+
+// Import regenerates the *.cgen.yml files from the vendor tool output in the
+// generator output directory without launching the vendor tool, e.g. in CI.
+func Import(cbuildGenIdxYmlPath, outPath string, options map[string]string) error {
+	gen, workDir, err := prepareOffline(cbuildGenIdxYmlPath, outPath, options)
+	if err != nil {
+		return err
+	}
+	return gen.WriteCgen(workDir)
+}
+
+// Check compares the *.cgen.yml and related files on disk with the files regenerated from
+// the vendor tool output and returns the report of the differences, empty if up to date
+func Check(cbuildGenIdxYmlPath, outPath string, options map[string]string) (string, error) {
+	gen, workDir, err := prepareOffline(cbuildGenIdxYmlPath, outPath, options)
+	if err != nil {
+		return "", err
+	}
+	checker, ok := gen.(generator.Checker)
+	if !ok {
+		return "", errors.New("generator '" + gen.ID() + "' does not support checking the generated files")
+	}
+	return checker.Check(workDir)
+}
+
+// Generate runs the vendor tool without user interaction until the code is generated
+// and writes the *.cgen.yml files. A bridge daemon serving the same work dir must be
+// stopped first, the vendor tool would write the files concurrently.
+func Generate(cbuildGenIdxYmlPath, outPath string, options map[string]string) error {
+	gen, workDir, err := prepareOffline(cbuildGenIdxYmlPath, outPath, options)
+	if err != nil {
+		return err
+	}
+	headless, ok := gen.(generator.Headless)
+	if !ok {
+		return errors.New("generator '" + gen.ID() + "' does not support headless code generation")
+	}
+
+	lock, holder, err := acquireLock(workDir, cbuildGenIdxYmlPath)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return fmt.Errorf("bridge daemon with pid %d is running for %v, close the generator first", holder, workDir)
+	}
+	lock.keepAlive() // no control channel while the generator runs
+	defer lock.release()
+	return headless.GenerateHeadless(workDir)
+}
+
+// prepareOffline selects and configures the backend for the commands not launching the
+// vendor tool interactively and returns it with the generator output directory
+func prepareOffline(cbuildGenIdxYmlPath, outPath string, options map[string]string) (generator.Generator, string, error) {
+	if !utils.FileExists(cbuildGenIdxYmlPath) {
+		return nil, "", errors.New("file not found: " + cbuildGenIdxYmlPath)
+	}
+
+	gen, err := SelectGenerator(cbuildGenIdxYmlPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = configureOptions(gen, options); err != nil {
+		return nil, "", err
+	}
+
+	generatorFile, err := FindGeneratorFile()
+	if err != nil {
+		// the vendor tool is not installed, the built-in settings are used
+		log.Debugf("%v", err)
+	} else if _, err = configureGenerator(gen, generatorFile); err != nil {
+		return nil, "", err
+	}
+
+	workDir, err := ReadBridgeParams(gen, cbuildGenIdxYmlPath, outPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return gen, workDir, nil
+}
+
+// configureGenerator reads the generator settings of global.generator.yml and
+// applies the toolchains, filters and groups sections to the backend, at once if it
+// is Configurable. A missing section resets the backend to its built-in settings,
+// e.g. on a daemon reload.
+func configureGenerator(gen generator.Generator, generatorFile string) (generator.ParamsType, error) {
+	var gParms generator.ParamsType
+	err := ReadGeneratorYmlFile(generatorFile, gen.ID(), &gParms)
+	if errors.Is(err, generator.ErrGeneratorMissing) {
+		// only the download hint is taken from the generator entry
+		log.Warnf("%v", err)
+		gParms.ID = gen.ID()
+	} else if err != nil {
+		return gParms, err
+	}
+	if configurable, ok := gen.(generator.Configurable); ok {
+		return gParms, configurable.Configure(gParms)
+	}
+	if configurable, ok := gen.(generator.ToolchainConfigurable); ok {
+		err = configurable.SetToolchains(gParms.Toolchains)
+		if err != nil {
+			return gParms, err
+		}
+	}
+	if configurable, ok := gen.(generator.FilterConfigurable); ok {
+		err = configurable.SetFilters(gParms.Filters)
+		if err != nil {
+			return gParms, err
+		}
+	}
+	if configurable, ok := gen.(generator.GroupConfigurable); ok {
+		err = configurable.SetGroups(gParms.Groups)
+		if err != nil {
+			return gParms, err
+		}
+	}
+	return gParms, nil
+}
+
+// configureOptions passes the command line options to the backend, which keeps them
+// when global.generator.yml is read again
+func configureOptions(gen generator.Generator, options map[string]string) error {
+	configurable, ok := gen.(generator.OptionConfigurable)
+	if !ok {
+		if len(options) > 0 {
+			return fmt.Errorf("generator '%s' does not support the options %v", gen.ID(), slices.Sorted(maps.Keys(options)))
+		}
+		return nil
+	}
+	return configurable.SetOptions(options)
+}
+
+// FindGeneratorFile searches global.generator.yml below CMSIS_COMPILER_ROOT or,
+// if not set, below the parent folder of the executable.
+func FindGeneratorFile() (string, error) {
+	cRoot := os.Getenv("CMSIS_COMPILER_ROOT")
+	if len(cRoot) == 0 {
+		ex, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		exPath := filepath.Dir(ex)
+		cRoot = filepath.Dir(exPath)
+	}
+
+	// Validate and sanitize cRoot path to prevent path traversal
+	cRoot = filepath.Clean(cRoot)
+	var err error
+	cRoot, err = filepath.Abs(cRoot)
+	if err != nil {
+		return "", fmt.Errorf("invalid CMSIS_COMPILER_ROOT path: %w", err)
+	}
+	if !utils.DirExists(cRoot) {
+		return "", fmt.Errorf("CMSIS_COMPILER_ROOT directory does not exist: %s", cRoot)
+	}
+
+	var generatorFile string
+	// #nosec G703 -- cRoot is validated via filepath.Clean, filepath.Abs, and DirExists checks
+	err = filepath.Walk(cRoot, func(path string, f fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if f == nil {
+			return errors.New("unexpected nil FileInfo for path: " + path)
+		}
+		if f.Mode().IsRegular() && strings.Contains(path, "global.generator.yml") {
+			generatorFile = path
+			return nil
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(generatorFile) == 0 {
+		return "", errors.New("config file 'global.generator.yml' not found")
+	}
+	return generatorFile, nil
+}
+
+// SelectGenerator creates the backend for the first generator id in the
+// cbuild-gen-idx.yml file that has a registered backend.
+func SelectGenerator(cbuildGenIdxYmlPath string) (generator.Generator, error) {
+	ids, err := cbuild.ReadGeneratorIDs(cbuildGenIdxYmlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if generator.IsRegistered(id) {
+			log.Debugf("Selected generator backend: %v", id)
+			return generator.New(id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no generator found in " + cbuildGenIdxYmlPath)
+	}
+	return generator.New(ids[0]) // reports the missing backend
+}
+
+func ReadGeneratorYmlFile(path, id string, parms *generator.ParamsType) error {
+	log.Debugf("Reading generator.yml file: '%v'", path)
+	err := generator.Read(path, id, parms)
+	return err
+}
+
+// ReadBridgeParams reads the cbuild-gen-idx.yml file for the generator, resolves
+// the backend parameters and creates the work directory.
+func ReadBridgeParams(gen generator.Generator, cbuildGenIdxYmlPath, outPath string) (string, error) {
+	log.Debugf("Reading cbuild-gen-idx.yml file: '%v'", cbuildGenIdxYmlPath)
+	var parms cbuild.ParamsType
+	err := cbuild.Read(cbuildGenIdxYmlPath, gen.ID(), &parms)
+	if err != nil {
+		return "", err
+	}
+
+	err = gen.ReadBridgeParams(&parms)
+	if err != nil {
+		return "", err
+	}
+
+	workDir := GetWorkDir(cbuildGenIdxYmlPath, parms.Output, outPath)
+	err = os.MkdirAll(workDir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+	return workDir, nil
+}
+
+// GetWorkDir returns the generator output directory. The output from the
+// cbuild-gen-idx.yml file takes precedence over the command line.
+func GetWorkDir(cbuildGenIdxYmlPath, output, outPath string) string {
+	workDir := filepath.Dir(cbuildGenIdxYmlPath)
+	if output != "" {
+		if filepath.IsAbs(output) {
+			workDir = output
+		} else {
+			workDir = filepath.Join(workDir, output)
+		}
+	} else {
+		if filepath.IsAbs(outPath) {
+			workDir = outPath
+		} else {
+			workDir = filepath.Join(workDir, outPath)
+		}
+	}
+	workDir = filepath.Clean(workDir)
+	workDir = filepath.ToSlash(workDir)
+	return workDir
+}
+
+// startDaemon restarts the own executable with the daemon arguments attached and returns
+// its pid. The daemon is detached from the terminal, its standard streams are the null device.
+func startDaemon(args ...string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	ownPath, err := filepath.EvalSymlinks((exe))
+	if err != nil {
+		return 0, err
+	}
+	cmd := exec.Command(ownPath) //nolint
+	cmd.Args = os.Args
+	cmd.Args = append(cmd.Args, args...)
+	cmd.SysProcAttr = detachedProcAttr()
+	log.Debugf("cmd.Start as %v", cmd)
+	if err := cmd.Start(); err != nil { // start myself as a daemon
+		log.Fatal(err)
+		return 0, err
+	}
+	return cmd.Process.Pid, nil
+}
@@ -0,0 +1,384 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+type fakeGenerator struct {
+	cbuildParams cbuild.ParamsType
+	cgenDir      string
+	stopped      bool
+	groups       []generator.GroupsType
+}
+
+var lastFake *fakeGenerator
+
+func (f *fakeGenerator) ID() string { return "Fake" }
+func (f *fakeGenerator) ReadBridgeParams(cbuildParams *cbuild.ParamsType) error {
+	f.cbuildParams = *cbuildParams
+	return nil
+}
+func (f *fakeGenerator) WriteLaunchProject(string) (string, error) { return "", nil }
+func (f *fakeGenerator) Launch(string, string) (int, error)        { return -1, nil }
+func (f *fakeGenerator) Watch(string, int) error                   { return nil }
+func (f *fakeGenerator) WriteCgen(workDir string) error {
+	f.cgenDir = workDir
+	return nil
+}
+
+func (f *fakeGenerator) Stop() { f.stopped = true }
+
+func (f *fakeGenerator) SetGroups(groups []generator.GroupsType) error {
+	f.groups = groups
+	return nil
+}
+
+// fakeConfigurable takes all sections of global.generator.yml at once
+type fakeConfigurable struct {
+	fakeGenerator
+	params generator.ParamsType
+}
+
+func (f *fakeConfigurable) Configure(params generator.ParamsType) error {
+	f.params = params
+	return nil
+}
+
+// fakeSupervisor starts the test binary as generator
+type fakeSupervisor struct {
+	fakeGenerator
+	lockPid  int
+	exitCode int
+}
+
+var lastSupervisor *fakeSupervisor
+
+func (f *fakeSupervisor) ID() string { return "FakeSupervisor" }
+
+func (f *fakeSupervisor) Command(workDir, _ string) (*exec.Cmd, error) {
+	f.cgenDir = workDir
+	return exec.Command(os.Args[0], "-test.run=^$"), nil //nolint
+}
+
+func (f *fakeSupervisor) Supervise(workDir string, cmd *exec.Cmd) error {
+	f.lockPid = readLockPid(filepath.Join(workDir, lockFileName))
+	err := cmd.Wait()
+	f.exitCode = cmd.ProcessState.ExitCode()
+	return err
+}
+
+func init() {
+	generator.Register("Fake", func() generator.Generator {
+		lastFake = &fakeGenerator{}
+		return lastFake
+	})
+	generator.Register("FakeSupervisor", func() generator.Generator {
+		lastSupervisor = &fakeSupervisor{}
+		return lastSupervisor
+	})
+}
+
+// writeCbuildGenIdx creates a solution in a temporary CMSIS_COMPILER_ROOT with the
+// global.generator.yml and cbuild-gen-idx.yml entries of the generator. The extra lines
+// are added to the cbuild-gen-idx.yml entry, whose output is the gen folder.
+func writeCbuildGenIdx(t *testing.T, id, extra string) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("CMSIS_COMPILER_ROOT", root)
+	t.Setenv("XDG_CACHE_HOME", root) // control sockets
+
+	generatorYML := "generator:\n  - id: " + id + "\n    description: test\n    download-url: https://example.invalid\n"
+	if err := os.WriteFile(filepath.Join(root, "global.generator.yml"), []byte(generatorYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	idxYML := "build-gen-idx:\n  generators:\n    - id: " + id + "\n      output: gen\n" + extra
+	idxPath := filepath.Join(root, "test.cbuild-gen-idx.yml")
+	if err := os.WriteFile(idxPath, []byte(idxYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	return idxPath
+}
+
+func Test_ProcessEarlyFailures(t *testing.T) {
+	t.Run("missing_cmsis_compiler_root_dir", func(t *testing.T) {
+		t.Setenv("CMSIS_COMPILER_ROOT", filepath.Join(t.TempDir(), "missing"))
+
+		err := Process(filepath.Join(t.TempDir(), "unused.cbuild-gen-idx.yml"), "out", false, -1, nil)
+		if err == nil {
+			t.Fatal("Process() error = nil, want missing CMSIS_COMPILER_ROOT directory error")
+		}
+		if !strings.Contains(err.Error(), "CMSIS_COMPILER_ROOT directory does not exist") {
+			t.Fatalf("Process() error = %q, want missing CMSIS_COMPILER_ROOT directory", err)
+		}
+	})
+
+	t.Run("missing_global_generator_file", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("CMSIS_COMPILER_ROOT", root)
+
+		err := Process(filepath.Join(root, "unused.cbuild-gen-idx.yml"), "out", false, -1, nil)
+		if err == nil {
+			t.Fatal("Process() error = nil, want missing global.generator.yml error")
+		}
+		if !strings.Contains(err.Error(), "config file 'global.generator.yml' not found") {
+			t.Fatalf("Process() error = %q, want missing global.generator.yml", err)
+		}
+	})
+
+	t.Run("missing_cbuild_idx_after_generator_load", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("CMSIS_COMPILER_ROOT", root)
+
+		generatorPath := filepath.Join(root, "global.generator.yml")
+		generatorYML := "generator:\n  - id: CubeMX\n    description: test\n    download-url: https://example.invalid\n    run: ../bin/cbridge\n    path: $SolutionDir()$/STM32CubeMX/$TargetType$\n"
+		if err := os.WriteFile(generatorPath, []byte(generatorYML), 0600); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+
+		missingIdx := filepath.Join(root, "missing.cbuild-gen-idx.yml")
+		err := Process(missingIdx, "out", false, -1, nil)
+		if err == nil {
+			t.Fatal("Process() error = nil, want missing cbuild idx error")
+		}
+		errMsg := strings.ToLower(err.Error())
+		if !strings.Contains(errMsg, "file not found") && !strings.Contains(errMsg, "cannot find the file specified") && !strings.Contains(errMsg, "no such file or directory") {
+			t.Fatalf("Process() error = %q, want missing cbuild idx error", err)
+		}
+	})
+}
+
+func Test_ProcessSelectsBackend(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "Fake", "      device: DeviceX\n")
+	root := filepath.Dir(idxPath)
+	idxYML := "build-gen-idx:\n  generators:\n    - id: Unknown\n    - id: Fake\n      output: gen\n      device: DeviceX\n"
+	if err := os.WriteFile(idxPath, []byte(idxYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	if err := Process(idxPath, "", false, -1, nil); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if lastFake == nil || lastFake.cbuildParams.Device != "DeviceX" {
+		t.Fatalf("Process() did not pass cbuild params to backend: %+v", lastFake)
+	}
+	if _, err := os.Stat(filepath.Join(root, "gen")); err != nil {
+		t.Errorf("Process() work dir not created: %v", err)
+	}
+
+	idxYML = "build-gen-idx:\n  generators:\n    - id: Unknown\n"
+	if err := os.WriteFile(idxPath, []byte(idxYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	err := Process(idxPath, "", false, -1, nil)
+	if err == nil || !strings.Contains(err.Error(), "no generator backend registered for id 'Unknown'") {
+		t.Fatalf("Process() error = %v, want missing backend", err)
+	}
+}
+
+func Test_configureGenerator(t *testing.T) {
+	generatorFile := filepath.Join(t.TempDir(), "global.generator.yml")
+
+	generatorYML := "generator:\n  - id: Other\n    download-url: https://example.invalid\n"
+	if err := os.WriteFile(generatorFile, []byte(generatorYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	gParms, err := configureGenerator(&fakeGenerator{}, generatorFile)
+	if err != nil || gParms.ID != "Fake" {
+		t.Errorf("configureGenerator() = %+v, %v, want missing generator entry tolerated", gParms, err)
+	}
+
+	gen := &fakeGenerator{}
+	generatorYML = "generator:\n  - id: Fake\ngroups:\n  - layout: tree\n"
+	if err = os.WriteFile(generatorFile, []byte(generatorYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err = configureGenerator(gen, generatorFile); err != nil || len(gen.groups) != 1 {
+		t.Errorf("configureGenerator() groups = %+v, %v", gen.groups, err)
+	}
+	if err = os.WriteFile(generatorFile, []byte("generator:\n  - id: Fake\n"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err = configureGenerator(gen, generatorFile); err != nil || gen.groups != nil {
+		t.Errorf("configureGenerator() kept the removed groups %+v, %v", gen.groups, err)
+	}
+
+	configurable := &fakeConfigurable{}
+	if err = os.WriteFile(generatorFile, []byte(generatorYML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err = configureGenerator(configurable, generatorFile); err != nil || len(configurable.params.Groups) != 1 || configurable.groups != nil {
+		t.Errorf("configureGenerator() = %v, configured %+v, groups %+v, want all sections at once", err, configurable.params, configurable.groups)
+	}
+
+	if err = os.WriteFile(generatorFile, []byte("generator: [\n"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err = configureGenerator(&fakeGenerator{}, generatorFile); err == nil {
+		t.Errorf("configureGenerator() error = nil, want malformed global.generator.yml")
+	}
+}
+
+func Test_Import(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "Fake", "      device: DeviceX\n")
+	root := filepath.Dir(idxPath)
+	if err := os.Remove(filepath.Join(root, "global.generator.yml")); err != nil { // the vendor tool is not installed
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+
+	if err := Import(idxPath, "", nil); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if lastFake == nil || lastFake.cbuildParams.Device != "DeviceX" {
+		t.Fatalf("Import() did not pass cbuild params to backend: %+v", lastFake)
+	}
+	if want := filepath.Join(root, "gen"); filepath.Clean(lastFake.cgenDir) != want {
+		t.Errorf("Import() wrote cgen.yml to %q, want %q", lastFake.cgenDir, want)
+	}
+
+	err := Import(filepath.Join(root, "missing.cbuild-gen-idx.yml"), "", nil)
+	if err == nil || !strings.Contains(err.Error(), "file not found") {
+		t.Fatalf("Import() error = %v, want missing cbuild idx", err)
+	}
+
+	err = Import(idxPath, "", map[string]string{"layout": "cmake"})
+	if err == nil || !strings.Contains(err.Error(), "does not support the options [layout]") {
+		t.Errorf("Import() error = %v, want unsupported options", err)
+	}
+}
+
+func Test_CheckUnsupported(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "Fake", "")
+
+	_, err := Check(idxPath, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "does not support checking") {
+		t.Fatalf("Check() error = %v, want unsupported backend", err)
+	}
+}
+
+func Test_GenerateUnsupported(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "Fake", "")
+
+	err := Generate(idxPath, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "does not support headless") {
+		t.Fatalf("Generate() error = %v, want unsupported backend", err)
+	}
+	if _, err = os.Stat(filepath.Join(filepath.Dir(idxPath), "gen", lockFileName)); err == nil {
+		t.Errorf("Generate() left the lock of an unsupported backend")
+	}
+}
+
+func Test_ProcessHandOff(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pid 1 is not a running process on Windows")
+	}
+	idxPath := writeCbuildGenIdx(t, "Fake", "")
+	workDir := filepath.Join(filepath.Dir(idxPath), "gen")
+	if err := os.MkdirAll(workDir, 0750); err != nil {
+		t.Fatalf("os.MkdirAll() error = %v", err)
+	}
+	lockPath := filepath.Join(workDir, lockFileName)
+	if err := os.WriteFile(lockPath, []byte("1"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	err := Process(idxPath, "", true, -1, nil)
+	if err == nil || !strings.Contains(err.Error(), "bridge already running with pid 1") {
+		t.Fatalf("Process() error = %v, want running bridge without control channel", err)
+	}
+
+	running := &fakeGenerator{}
+	server, err := startControlServer(running, idxPath, "", workDir, 1234)
+	if err != nil {
+		t.Fatalf("startControlServer() error = %v", err)
+	}
+	defer server.Close()
+	if err = Process(idxPath, "", true, -1, nil); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if running.cgenDir != workDir {
+		t.Errorf("Process() did not hand off to the running bridge, cgen dir %q", running.cgenDir)
+	}
+	if lastFake.cgenDir != "" {
+		t.Errorf("Process() launched a second generator")
+	}
+	if pid := readLockPid(lockPath); pid != 1 {
+		t.Errorf("Process() changed the lock to pid %d", pid)
+	}
+
+	if err = os.Remove(lockPath); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	if err = Process(idxPath, "", true, -1, nil); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if lastFake.cgenDir != workDir {
+		t.Errorf("Process() did not write the cgen.yml files of the finished generator")
+	}
+	if _, err = os.Stat(lockPath); err == nil {
+		t.Errorf("Process() did not release %v", lockPath)
+	}
+}
+
+func Test_Supervise(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "FakeSupervisor", "")
+	if err := Supervise(idxPath, "", "launch.file", nil); err != nil {
+		t.Fatalf("Supervise() error = %v", err)
+	}
+	workDir := filepath.Join(filepath.Dir(idxPath), "gen")
+	if lastSupervisor.cgenDir != workDir {
+		t.Errorf("Supervise() created the command for %q, want %q", lastSupervisor.cgenDir, workDir)
+	}
+	if lastSupervisor.lockPid != os.Getpid() || lastSupervisor.exitCode != 0 {
+		t.Errorf("Supervise() lock pid = %d, exit code = %d", lastSupervisor.lockPid, lastSupervisor.exitCode)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, lockFileName)); err == nil {
+		t.Errorf("Supervise() did not release the lock")
+	}
+
+	idxPath = writeCbuildGenIdx(t, "Fake", "")
+	if err := Supervise(idxPath, "", "launch.file", nil); err == nil || !strings.Contains(err.Error(), "cannot be supervised") {
+		t.Errorf("Supervise() error = %v, want backend without supervisor", err)
+	}
+}
+
+func TestGetWorkDir(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.ToSlash(t.TempDir())
+	idx := base + "/a/b/x.cbuild-gen-idx.yml"
+	tests := []struct {
+		name   string
+		idx    string
+		output string
+		out    string
+		want   string
+	}{
+		{"output", idx, "gen", "out", base + "/a/b/gen"},
+		{"absOutput", idx, base + "/c/gen", "out", base + "/c/gen"},
+		{"outPath", idx, "", "out", base + "/a/b/out"},
+		{"absOutPath", idx, "", base + "/d/out", base + "/d/out"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := GetWorkDir(tt.idx, tt.output, tt.out); got != filepath.ToSlash(filepath.Clean(tt.want)) {
+				t.Errorf("GetWorkDir() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !windows
+
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+)
+
+// controlDir is the folder of the control sockets in the user cache directory
+const controlDir = "generator-bridge"
+
+// controlPath returns the Unix domain socket of the control channel. It is placed in the
+// user cache directory, which only the user can access, so other users cannot send commands.
+func controlPath(name string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, controlDir)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	if err = os.Chmod(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".sock"), nil
+}
+
+// listenControl listens on the control socket, errControlInUse if a daemon serves it.
+// The socket is removed when the listener is closed.
+func listenControl(path string) (net.Listener, error) {
+	if utils.FileExists(path) {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, errControlInUse
+		}
+		_ = os.Remove(path) // left over from a daemon that did not exit cleanly
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// dialControl connects to the control socket
+func dialControl(path string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("unix", path, timeout)
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// pipePrefix is the namespace of the named pipes on the local machine
+const pipePrefix = `\\.\pipe\`
+
+// controlPath returns the named pipe of the control channel
+func controlPath(name string) (string, error) {
+	return pipePrefix + name, nil
+}
+
+// pipeAddr is the address of a named pipe
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+// pipeConn is a connected instance of the named pipe. The handle is opened for
+// overlapped I/O, so the deadlines of the file are supported.
+type pipeConn struct {
+	*os.File
+	addr pipeAddr
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr { return c.addr }
+
+// pipeListener creates a pipe instance per client. Only the user running the daemon
+// can connect, the pipe does not accept clients of other machines.
+type pipeListener struct {
+	mutex  sync.Mutex // serializes Accept and Close
+	path   string
+	sa     *windows.SecurityAttributes
+	handle windows.Handle // instance waiting for the next client
+	closed windows.Handle // event signaled by Close
+	once   sync.Once
+}
+
+// listenControl creates the first instance of the named pipe, errControlInUse if a
+// daemon serves it
+func listenControl(path string) (net.Listener, error) {
+	sa, err := userSecurityAttributes()
+	if err != nil {
+		return nil, err
+	}
+	closed, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	listener := &pipeListener{path: path, sa: sa, closed: closed}
+	listener.handle, err = listener.createPipe(windows.FILE_FLAG_FIRST_PIPE_INSTANCE)
+	if err != nil {
+		_ = windows.CloseHandle(closed)
+		if errors.Is(err, windows.ERROR_ACCESS_DENIED) || errors.Is(err, windows.ERROR_PIPE_BUSY) {
+			return nil, errControlInUse
+		}
+		return nil, err
+	}
+	return listener, nil
+}
+
+// userSecurityAttributes grants access to the pipe to the user of the process only
+func userSecurityAttributes() (*windows.SecurityAttributes, error) {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+	sd, err := windows.SecurityDescriptorFromString("D:P(A;;GA;;;" + user.User.Sid.String() + ")")
+	if err != nil {
+		return nil, err
+	}
+	sa := &windows.SecurityAttributes{SecurityDescriptor: sd}
+	sa.Length = uint32(unsafe.Sizeof(*sa)) //nolint:gosec // size of the Windows structure
+	return sa, nil
+}
+
+func (l *pipeListener) createPipe(flags uint32) (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(l.path)
+	if err != nil {
+		return windows.InvalidHandle, err
+	}
+	return windows.CreateNamedPipe(name, windows.PIPE_ACCESS_DUPLEX|windows.FILE_FLAG_OVERLAPPED|flags,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		windows.PIPE_UNLIMITED_INSTANCES, 4096, 4096, 0, l.sa)
+}
+
+// Accept waits for a client on the pipe instance and creates the next instance
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.handle == windows.InvalidHandle {
+		return nil, net.ErrClosed
+	}
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(event) //nolint:errcheck
+	overlapped := windows.Overlapped{HEvent: event}
+	err = windows.ConnectNamedPipe(l.handle, &overlapped)
+	if errors.Is(err, windows.ERROR_IO_PENDING) {
+		index, err := windows.WaitForMultipleObjects([]windows.Handle{event, l.closed}, false, windows.INFINITE)
+		if err != nil {
+			return nil, err
+		}
+		var done uint32
+		if index != windows.WAIT_OBJECT_0 {
+			_ = windows.CancelIoEx(l.handle, &overlapped)
+			_ = windows.GetOverlappedResult(l.handle, &overlapped, &done, true)
+			return nil, net.ErrClosed
+		}
+		err = windows.GetOverlappedResult(l.handle, &overlapped, &done, false)
+	}
+	if err != nil && !errors.Is(err, windows.ERROR_PIPE_CONNECTED) {
+		return nil, err
+	}
+
+	conn := &pipeConn{File: os.NewFile(uintptr(l.handle), l.path), addr: pipeAddr(l.path)}
+	l.handle, err = l.createPipe(0)
+	if err != nil {
+		l.handle = windows.InvalidHandle // the next Accept reports the listener closed
+	}
+	return conn, nil
+}
+
+// Close stops waiting for clients, connected clients are served further
+func (l *pipeListener) Close() error {
+	l.once.Do(func() {
+		_ = windows.SetEvent(l.closed)
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if l.handle != windows.InvalidHandle {
+			_ = windows.CloseHandle(l.handle)
+			l.handle = windows.InvalidHandle
+		}
+		_ = windows.CloseHandle(l.closed)
+	})
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return pipeAddr(l.path) }
+
+// dialControl opens the named pipe, waiting while all instances are busy. The daemon
+// may identify the client but not impersonate it.
+func dialControl(path string, timeout time.Duration) (net.Conn, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		handle, err := windows.CreateFile(name, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+			windows.FILE_FLAG_OVERLAPPED|windows.SECURITY_SQOS_PRESENT|windows.SECURITY_IDENTIFICATION, 0)
+		if err == nil {
+			return &pipeConn{File: os.NewFile(uintptr(handle), path), addr: pipeAddr(path)}, nil
+		}
+		if !errors.Is(err, windows.ERROR_PIPE_BUSY) || time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
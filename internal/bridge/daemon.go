@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	log "github.com/sirupsen/logrus"
+)
+
+// Commands of the daemon control channel
+const (
+	DaemonStatus     = "status"
+	DaemonRegenerate = "regenerate"
+	DaemonReload     = "reload"
+	DaemonStop       = "stop"
+)
+
+// DaemonRequest is sent by a client as one JSON line
+type DaemonRequest struct {
+	Command string `json:"command"`
+}
+
+// DaemonResponse is the JSON line the daemon answers a request with
+type DaemonResponse struct {
+	OK     bool         `json:"ok"`
+	Error  string       `json:"error,omitempty"`
+	Status *DaemonState `json:"status,omitempty"`
+}
+
+// DaemonState describes a running bridge daemon
+type DaemonState struct {
+	PID           int       `json:"pid"`
+	Generator     string    `json:"generator"`
+	GeneratorPID  int       `json:"generator-pid"`
+	CbuildGenIdx  string    `json:"cbuild-gen-idx"`
+	WorkDir       string    `json:"work-dir"`
+	Started       time.Time `json:"started"`
+	LastGenerated time.Time `json:"last-generated,omitzero"`
+	LastError     string    `json:"last-error,omitempty"`
+}
+
+// daemonTimeout limits a request, a regeneration reads all generated files
+const daemonTimeout = time.Minute
+
+// errControlInUse is returned by listenControl if a daemon serves the control channel
+var errControlInUse = errors.New("control channel in use")
+
+// SocketPath returns the control channel of the daemon serving a solution, a Unix domain
+// socket accessible by the user only or a named pipe on Windows. The name is derived from
+// the cbuild-gen-idx.yml file, which is unique per solution.
+func SocketPath(cbuildGenIdxYmlPath string) (string, error) {
+	path, err := filepath.Abs(cbuildGenIdxYmlPath)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(filepath.ToSlash(filepath.Clean(path))))
+	return controlPath("cbridge-" + hex.EncodeToString(hash[:8]))
+}
+
+// controlServer serves the daemon control channel while the backend watches the vendor tool
+type controlServer struct {
+	mutex               sync.Mutex // serializes the requests
+	listener            net.Listener
+	socketPath          string
+	gen                 generator.Generator
+	cbuildGenIdxYmlPath string
+	outPath             string
+	state               DaemonState
+}
+
+// startControlServer listens on the control socket of the solution
+func startControlServer(gen generator.Generator, cbuildGenIdxYmlPath, outPath, workDir string, pid int) (*controlServer, error) {
+	socketPath, err := SocketPath(cbuildGenIdxYmlPath)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := listenControl(socketPath)
+	if errors.Is(err, errControlInUse) {
+		return nil, errors.New("bridge daemon already running for " + cbuildGenIdxYmlPath)
+	} else if err != nil {
+		return nil, err
+	}
+	server := &controlServer{
+		listener:            listener,
+		socketPath:          socketPath,
+		gen:                 gen,
+		cbuildGenIdxYmlPath: cbuildGenIdxYmlPath,
+		outPath:             outPath,
+		state: DaemonState{
+			PID:          os.Getpid(),
+			Generator:    gen.ID(),
+			GeneratorPID: pid,
+			CbuildGenIdx: cbuildGenIdxYmlPath,
+			WorkDir:      workDir,
+			Started:      time.Now(),
+		},
+	}
+	log.Debugf("Daemon control channel: %v", socketPath)
+	go server.serve()
+	return server, nil
+}
+
+// Close stops serving requests and removes the control socket
+func (s *controlServer) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *controlServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *controlServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(daemonTimeout))
+
+	var request DaemonRequest
+	var response DaemonResponse
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &request)
+	}
+	if err != nil {
+		response.Error = "invalid request: " + err.Error()
+	} else {
+		response = s.handle(request)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	_, _ = conn.Write(append(data, '\n'))
+}
+
+func (s *controlServer) handle(request DaemonRequest) DaemonResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	log.Debugf("Daemon request: %v", request.Command)
+	var err error
+	switch request.Command {
+	case DaemonStatus:
+	case DaemonRegenerate:
+		err = s.gen.WriteCgen(s.state.WorkDir)
+		s.state.LastGenerated = time.Now()
+		s.state.LastError = ""
+		if err != nil {
+			s.state.LastError = err.Error()
+		}
+	case DaemonReload:
+		err = s.reload()
+	case DaemonStop:
+		stoppable, ok := s.gen.(generator.Stoppable)
+		if !ok {
+			err = errors.New("generator '" + s.gen.ID() + "' cannot be stopped")
+			break
+		}
+		stoppable.Stop()
+	default:
+		err = errors.New("unknown command '" + request.Command + "'")
+	}
+
+	if err != nil {
+		return DaemonResponse{Error: err.Error()}
+	}
+	state := s.state
+	return DaemonResponse{OK: true, Status: &state}
+}
+
+// reload reads global.generator.yml and the cbuild-gen-idx.yml file again
+func (s *controlServer) reload() error {
+	generatorFile, err := FindGeneratorFile()
+	if err != nil {
+		return err
+	}
+	if _, err = configureGenerator(s.gen, generatorFile); err != nil {
+		return err
+	}
+	workDir, err := ReadBridgeParams(s.gen, s.cbuildGenIdxYmlPath, s.outPath)
+	if err != nil {
+		return err
+	}
+	s.state.WorkDir = workDir
+	return nil
+}
+
+// SendDaemonCommand sends a command to the daemon serving the solution of the
+// cbuild-gen-idx.yml file and returns its response
+func SendDaemonCommand(cbuildGenIdxYmlPath, command string) (DaemonResponse, error) {
+	var response DaemonResponse
+	socketPath, err := SocketPath(cbuildGenIdxYmlPath)
+	if err != nil {
+		return response, err
+	}
+	conn, err := dialControl(socketPath, time.Second)
+	if err != nil {
+		return response, errors.New("no bridge daemon running for " + cbuildGenIdxYmlPath)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(daemonTimeout))
+
+	data, err := json.Marshal(DaemonRequest{Command: command})
+	if err != nil {
+		return response, err
+	}
+	if _, err = conn.Write(append(data, '\n')); err != nil {
+		return response, err
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return response, err
+	}
+	if err = json.Unmarshal(line, &response); err != nil {
+		return response, err
+	}
+	if !response.OK {
+		return response, errors.New(response.Error)
+	}
+	return response, nil
+}
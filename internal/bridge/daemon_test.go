@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_SocketPath(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	one, err := SocketPath(filepath.Join("solution", "tmp", "one.cbuild-gen-idx.yml"))
+	if err != nil {
+		t.Fatalf("SocketPath() error = %v", err)
+	}
+	again, _ := SocketPath(filepath.Join("solution", "tmp", ".", "one.cbuild-gen-idx.yml"))
+	other, _ := SocketPath(filepath.Join("solution", "tmp", "other.cbuild-gen-idx.yml"))
+	if one != again {
+		t.Errorf("SocketPath() = %v and %v for the same solution", one, again)
+	}
+	if one == other {
+		t.Errorf("SocketPath() = %v for different solutions", one)
+	}
+
+	if runtime.GOOS == "windows" {
+		if !strings.HasPrefix(one, `\\.\pipe\cbridge-`) {
+			t.Errorf("SocketPath() = %v, want named pipe", one)
+		}
+		return
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Fatalf("os.UserCacheDir() error = %v", err)
+	}
+	if filepath.Dir(one) != filepath.Join(cacheDir, "generator-bridge") || !strings.HasSuffix(one, ".sock") {
+		t.Errorf("SocketPath() = %v, want socket in %v", one, filepath.Join(cacheDir, "generator-bridge"))
+	}
+	if info, err := os.Stat(filepath.Dir(one)); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("SocketPath() folder = %v, %v, want accessible by the user only", info.Mode(), err)
+	}
+}
+
+func Test_controlServer(t *testing.T) {
+	idxPath := writeCbuildGenIdx(t, "Fake", "      device: DeviceX\n")
+	socketPath, err := SocketPath(idxPath)
+	if err != nil {
+		t.Fatalf("SocketPath() error = %v", err)
+	}
+	if runtime.GOOS != "windows" {
+		if err = os.WriteFile(socketPath, nil, 0600); err != nil { // left over socket
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	gen := &fakeGenerator{}
+	workDir := filepath.Join(filepath.Dir(idxPath), "gen")
+	server, err := startControlServer(gen, idxPath, "", workDir, 1234)
+	if err != nil {
+		t.Fatalf("startControlServer() error = %v", err)
+	}
+	defer server.Close()
+
+	if _, err = startControlServer(gen, idxPath, "", workDir, 1234); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("startControlServer() error = %v, want daemon already running", err)
+	}
+
+	response, err := SendDaemonCommand(idxPath, DaemonStatus)
+	if err != nil {
+		t.Fatalf("SendDaemonCommand(status) error = %v", err)
+	}
+	if state := response.Status; state == nil || state.PID != os.Getpid() || state.Generator != "Fake" || state.GeneratorPID != 1234 || state.WorkDir != workDir || !state.LastGenerated.IsZero() {
+		t.Errorf("SendDaemonCommand(status) = %+v", response.Status)
+	}
+
+	response, err = SendDaemonCommand(idxPath, DaemonRegenerate)
+	if err != nil {
+		t.Fatalf("SendDaemonCommand(regenerate) error = %v", err)
+	}
+	if gen.cgenDir != workDir || response.Status.LastGenerated.IsZero() {
+		t.Errorf("SendDaemonCommand(regenerate) wrote to %q, status %+v", gen.cgenDir, response.Status)
+	}
+
+	if _, err = SendDaemonCommand(idxPath, DaemonReload); err != nil {
+		t.Fatalf("SendDaemonCommand(reload) error = %v", err)
+	}
+	if gen.cbuildParams.Device != "DeviceX" {
+		t.Errorf("SendDaemonCommand(reload) did not read %v", idxPath)
+	}
+
+	if _, err = SendDaemonCommand(idxPath, "restart"); err == nil || !strings.Contains(err.Error(), "unknown command 'restart'") {
+		t.Errorf("SendDaemonCommand(restart) error = %v, want unknown command", err)
+	}
+
+	if _, err = SendDaemonCommand(idxPath, DaemonStop); err != nil || !gen.stopped {
+		t.Errorf("SendDaemonCommand(stop) error = %v, stopped = %v", err, gen.stopped)
+	}
+
+	server.Close()
+	if _, err = SendDaemonCommand(idxPath, DaemonStatus); err == nil || !strings.Contains(err.Error(), "no bridge daemon running") {
+		t.Errorf("SendDaemonCommand() error = %v, want no daemon", err)
+	}
+	if _, err = os.Stat(socketPath); err == nil && runtime.GOOS != "windows" {
+		t.Errorf("Close() did not remove %v", socketPath)
+	}
+}
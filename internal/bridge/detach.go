@@ -0,0 +1,16 @@
+//go:build !windows
+
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import "syscall"
+
+// detachedProcAttr starts the daemon in a new session without controlling terminal
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
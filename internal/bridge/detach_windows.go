@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import "syscall"
+
+const detachedProcess = 0x00000008 // DETACHED_PROCESS, not defined in package syscall
+
+// detachedProcAttr starts the daemon without console in its own process group
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess | syscall.CREATE_NEW_PROCESS_GROUP}
+}
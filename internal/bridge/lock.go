@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// lockFileName is the file in the work dir holding the pid of the bridge serving it
+const lockFileName = ".cbridge.lock"
+
+// A lock not refreshed within lockGrace and without control channel answering is stale,
+// its pid may be reused by another process. Holders without control channel refresh it.
+const (
+	lockGrace   = 30 * time.Second
+	lockRefresh = 10 * time.Second
+)
+
+// instanceLock makes sure only one bridge launches the generator and watches a work dir
+type instanceLock struct {
+	path string
+	done chan struct{} // ends keepAlive
+}
+
+// acquireLock creates the lock file in the work dir. If another running bridge holds
+// the lock, no lock and the pid of the holder are returned. A lock of a process that
+// does not run anymore is stale and cleared, as is an older lock of a running process
+// that does not answer on the control channel of the solution. The daemon takes over
+// the lock of the bridge that started it.
+func acquireLock(workDir, cbuildGenIdxYmlPath string) (*instanceLock, int, error) {
+	path := filepath.Join(workDir, lockFileName)
+	for range 2 {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = file.WriteString(strconv.Itoa(os.Getpid()))
+			closeErr := file.Close()
+			if err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, 0, err
+			}
+			return &instanceLock{path: path}, 0, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, 0, err
+		}
+
+		holder := readLockPid(path)
+		switch {
+		case holder == os.Getpid():
+			return &instanceLock{path: path}, 0, nil
+		case holder == os.Getppid():
+			if err = os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0600); err != nil {
+				return nil, 0, err
+			}
+			log.Debugf("Took over lock %v from pid %d", path, holder)
+			return &instanceLock{path: path}, 0, nil
+		case utils.ProcessRunning(holder) && !lockAbandoned(path, cbuildGenIdxYmlPath):
+			return nil, holder, nil
+		}
+		log.Infof("Removing stale lock %v of pid %d", path, holder)
+		if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, 0, err
+		}
+	}
+	return nil, 0, errors.New("cannot acquire lock " + path)
+}
+
+// lockAbandoned checks the lock of a running process, which is abandoned if it was not
+// refreshed recently and no daemon answers on the control channel
+func lockAbandoned(path, cbuildGenIdxYmlPath string) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) < lockGrace {
+		return false
+	}
+	_, err = SendDaemonCommand(cbuildGenIdxYmlPath, DaemonStatus)
+	return err != nil
+}
+
+// readLockPid returns the pid in the lock file, 0 if unreadable
+func readLockPid(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// handOver writes the pid of the started daemon to the lock, so it does not name this
+// bridge after it exits. A lock the daemon took over already is left as it is.
+func (l *instanceLock) handOver(pid int) error {
+	if readLockPid(l.path) != os.Getpid() {
+		return nil
+	}
+	return os.WriteFile(l.path, []byte(strconv.Itoa(pid)), 0600)
+}
+
+// keepAlive refreshes the lock until it is released, for holders without control channel
+func (l *instanceLock) keepAlive() {
+	l.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lockRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.done:
+				return
+			case now := <-ticker.C:
+				_ = os.Chtimes(l.path, now, now)
+			}
+		}
+	}()
+}
+
+// release removes the lock file if it is still held by this process
+func (l *instanceLock) release() {
+	if l.done != nil {
+		close(l.done)
+		l.done = nil
+	}
+	if readLockPid(l.path) != os.Getpid() {
+		return
+	}
+	if err := os.Remove(l.path); err != nil {
+		log.Debugf("failed to remove lock %v: %v", l.path, err)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bridge
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// endedPid returns the pid of a process that does not run anymore
+func endedPid(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^$") //nolint
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("cmd.Run() error = %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func Test_acquireLock(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	lockPath := filepath.Join(workDir, lockFileName)
+	idxPath := filepath.Join(workDir, "test.cbuild-gen-idx.yml")
+
+	lock, holder, err := acquireLock(workDir, idxPath)
+	if err != nil || lock == nil || holder != 0 {
+		t.Fatalf("acquireLock() = %v, %d, %v, want lock", lock, holder, err)
+	}
+	if pid := readLockPid(lockPath); pid != os.Getpid() {
+		t.Errorf("acquireLock() wrote pid %d, want %d", pid, os.Getpid())
+	}
+	if again, _, err := acquireLock(workDir, idxPath); err != nil || again == nil {
+		t.Errorf("acquireLock() = %v, %v, want lock of own process", again, err)
+	}
+	lock.release()
+	if _, err = os.Stat(lockPath); err == nil {
+		t.Errorf("release() did not remove %v", lockPath)
+	}
+
+	stale := endedPid(t)
+	if err = os.WriteFile(lockPath, []byte(strconv.Itoa(stale)), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	lock, holder, err = acquireLock(workDir, idxPath)
+	if err != nil || lock == nil || holder != 0 {
+		t.Fatalf("acquireLock() = %v, %d, %v, want stale lock of pid %d cleared", lock, holder, err, stale)
+	}
+	if err = os.WriteFile(lockPath, []byte(strconv.Itoa(stale)), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	lock.release()
+	if _, err = os.Stat(lockPath); err != nil {
+		t.Errorf("release() removed the lock of pid %d", stale)
+	}
+
+	if err = os.Remove(lockPath); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	if lock, _, err = acquireLock(workDir, idxPath); err != nil || lock == nil {
+		t.Fatalf("acquireLock() = %v, %v, want lock", lock, err)
+	}
+	if err = lock.handOver(stale); err != nil || readLockPid(lockPath) != stale {
+		t.Errorf("handOver() = %v, lock pid %d, want %d", err, readLockPid(lockPath), stale)
+	}
+	if err = lock.handOver(os.Getpid()); err != nil || readLockPid(lockPath) != stale {
+		t.Errorf("handOver() = %v, overwrote the lock taken over by pid %d", err, stale)
+	}
+}
+
+func Test_acquireLockBusy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pid 1 is not a running process on Windows")
+	}
+	workDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", workDir)
+	lockPath := filepath.Join(workDir, lockFileName)
+	idxPath := filepath.Join(workDir, "test.cbuild-gen-idx.yml")
+
+	if err := os.WriteFile(lockPath, []byte("1"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	lock, holder, err := acquireLock(workDir, idxPath)
+	if err != nil || lock != nil || holder != 1 {
+		t.Errorf("acquireLock() = %v, %d, %v, want lock held by pid 1", lock, holder, err)
+	}
+
+	// pid 1 does not answer on the control channel, an older lock is reused pid
+	old := time.Now().Add(-2 * lockGrace)
+	if err = os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("os.Chtimes() error = %v", err)
+	}
+	lock, holder, err = acquireLock(workDir, idxPath)
+	if err != nil || lock == nil || holder != 0 {
+		t.Fatalf("acquireLock() = %v, %d, %v, want abandoned lock of pid 1 taken over", lock, holder, err)
+	}
+	lock.release()
+}
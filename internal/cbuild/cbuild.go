@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2023 Arm Limited. All rights reserved.
+ * Copyright (c) 2023-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -25,6 +25,7 @@ type CbuildGensType struct {
 }
 
 type ParamsType struct {
+	IdxFile     string
 	GeneratedBy string
 	ID          string
 	Output      string
@@ -72,7 +73,9 @@ type CbuildGenType struct {
 		Context     string `yaml:"context"`
 		Compiler    string `yaml:"compiler"`
 		Board       string `yaml:"board"`
+		BoardPack   string `yaml:"board-pack"`
 		Device      string `yaml:"device"`
+		DevicePack  string `yaml:"device-pack"`
 		Processor   struct {
 			Fpu       string `yaml:"fpu"`
 			Endian    string `yaml:"endian"`
@@ -149,20 +152,38 @@ type CgenType struct {
 type CgenPacksType struct {
 	Pack string `yaml:"pack,omitempty"`
 }
+type CgenMiscType struct {
+	ForCompiler string   `yaml:"for-compiler,omitempty"`
+	C           []string `yaml:"C,omitempty"`
+	CPP         []string `yaml:"CPP,omitempty"`
+	CCPP        []string `yaml:"C-CPP,omitempty"`
+	ASM         []string `yaml:"ASM,omitempty"`
+}
 type CgenFilesType struct {
-	File string `yaml:"file,omitempty"`
+	File     string          `yaml:"file,omitempty"`
+	Category string          `yaml:"category,omitempty"`
+	Define   []DefineElement `yaml:"define,omitempty"`
+	Misc     []CgenMiscType  `yaml:"misc,omitempty"`
 }
 type CgenGroupsType struct {
-	Group string          `yaml:"group,omitempty"`
-	Files []CgenFilesType `yaml:"files,omitempty"`
+	Group  string          `yaml:"group,omitempty"`
+	Define []DefineElement `yaml:"define,omitempty"`
+	Misc   []CgenMiscType  `yaml:"misc,omitempty"`
+	Files  []CgenFilesType `yaml:"files,omitempty"`
+}
+type CgenLinkerType struct {
+	Script      string `yaml:"script,omitempty"`
+	ForCompiler string `yaml:"for-compiler,omitempty"`
 }
 type GeneratorImportType struct {
-	ForDevice string           `yaml:"for-device,omitempty"`
-	ForBoard  string           `yaml:"for-board,omitempty"`
-	Packs     []CgenPacksType  `yaml:"packs,omitempty"` // do not set if no new packs
-	Define    []string         `yaml:"define,omitempty"`
-	AddPath   []string         `yaml:"add-path,omitempty"`
-	Groups    []CgenGroupsType `yaml:"groups,omitempty"`
+	GeneratedBy string           `yaml:"generated-by,omitempty"`
+	ForDevice   string           `yaml:"for-device,omitempty"`
+	ForBoard    string           `yaml:"for-board,omitempty"`
+	Packs       []CgenPacksType  `yaml:"packs,omitempty"` // do not set if no new packs
+	Define      []DefineElement  `yaml:"define,omitempty"`
+	AddPath     []string         `yaml:"add-path,omitempty"`
+	Groups      []CgenGroupsType `yaml:"groups,omitempty"`
+	Linker      []CgenLinkerType `yaml:"linker,omitempty"`
 }
 
 func (d *DefineElement) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -185,6 +206,18 @@ func (d *DefineElement) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// MarshalYAML writes a define without value as plain name and a define with value as NAME: VALUE
+func (d DefineElement) MarshalYAML() (interface{}, error) {
+	if len(d.NameValue) == 1 {
+		for name, value := range d.NameValue {
+			if value == "" {
+				return name, nil
+			}
+		}
+	}
+	return d.NameValue, nil
+}
+
 func Read(name, generatorID string, params *ParamsType) error {
 	return ReadCbuildgenIdx(name, generatorID, params)
 }
@@ -199,6 +232,7 @@ func ReadCbuildgenIdx(name, generatorID string, params *ParamsType) error {
 
 	for _, cgen := range cbuildGenIdx.BuildGenIdx.Generators {
 		if cgen.ID == generatorID {
+			params.IdxFile = name
 			params.GeneratedBy = cbuildGenIdx.BuildGenIdx.GeneratedBy
 			params.ID = cgen.ID
 			params.Output = cgen.Output
@@ -227,6 +261,26 @@ func ReadCbuildgenIdx(name, generatorID string, params *ParamsType) error {
 	return nil
 }
 
+// ReadGeneratorIDs returns the generator ids listed in a cbuild-gen-idx.yml file
+func ReadGeneratorIDs(name string) ([]string, error) {
+	var cbuildGenIdx CbuildGenIdxType
+
+	if !utils.FileExists(name) {
+		return nil, errors.New("File not found: " + name)
+	}
+
+	err := common.ReadYml(name, &cbuildGenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, cgen := range cbuildGenIdx.BuildGenIdx.Generators {
+		ids = append(ids, cgen.ID)
+	}
+	return ids, nil
+}
+
 func ReadCbuildgen(name string, cbuildGen *CbuildGenType) error {
 
 	if !utils.FileExists(name) {
@@ -5,3 +5,34 @@
  */
 
 package cbuild_test
+
+import (
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefineElementYAML(t *testing.T) {
+	t.Parallel()
+
+	defines := []cbuild.DefineElement{
+		{NameValue: map[string]string{"USE_HAL_DRIVER": ""}},
+		{NameValue: map[string]string{"HSE_VALUE": "8000000"}},
+	}
+	data, err := yaml.Marshal(defines)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := "- USE_HAL_DRIVER\n- HSE_VALUE: \"8000000\"\n"; string(data) != want {
+		t.Errorf("Marshal() = %q, want %q", data, want)
+	}
+
+	var got []cbuild.DefineElement
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 2 || got[0].NameValue["USE_HAL_DRIVER"] != "" || got[1].NameValue["HSE_VALUE"] != "8000000" {
+		t.Errorf("Unmarshal() = %v", got)
+	}
+}
@@ -4,7 +4,8 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-package stm32cubemx
+// Package cgenlog handles the *.cgen.log files reporting generator errors next to the *.cgen.yml files.
+package cgenlog
 
 import (
 	"fmt"
@@ -17,22 +18,23 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func getCgenLogPath(cgenPath string) string {
+// GetPath returns the *.cgen.log path for a given cgen path.
+func GetPath(cgenPath string) string {
 	return strings.TrimSuffix(cgenPath, ".yml") + ".log"
 }
 
-// deleteCgenLog deletes the *.cgen.log file for a given cgen path.
-func deleteCgenLog(cgenPath string) error {
-	logPath := getCgenLogPath(cgenPath)
+// Delete deletes the *.cgen.log file for a given cgen path.
+func Delete(cgenPath string) error {
+	logPath := GetPath(cgenPath)
 	if utils.FileExists(logPath) {
 		return os.Remove(logPath)
 	}
 	return nil
 }
 
-// logCgenError appends an error message with timestamp and function name to the *.cgen.log file.
-func logCgenError(cgenPath string, err error) {
-	logPath := getCgenLogPath(cgenPath)
+// Error appends an error message with timestamp and function name to the *.cgen.log file.
+func Error(cgenPath string, err error) {
+	logPath := GetPath(cgenPath)
 
 	// Get caller's function name.
 	pc, _, _, _ := runtime.Caller(1)
@@ -61,19 +63,24 @@ func logCgenError(cgenPath string, err error) {
 	}
 }
 
-// logCgenInfoIfLogExists appends an info message only when the cgen log already
+// InfoIfExists appends an info message only when the cgen log already
 // exists. This is used in daemon mode to acknowledge recovery after prior
 // errors/warnings in the same session.
-func logCgenInfoIfLogExists(cgenPath, message string) {
-	logPath := getCgenLogPath(cgenPath)
-	if !utils.FileExists(logPath) {
+func InfoIfExists(cgenPath, message string) {
+	if !utils.FileExists(GetPath(cgenPath)) {
 		return
 	}
+	Info(cgenPath, message)
+}
+
+// Info appends an info message with timestamp to the *.cgen.log file.
+func Info(cgenPath, message string) {
+	logPath := GetPath(cgenPath)
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05.000")
 	entry := fmt.Sprintf("[%s] info: %s\n", timestamp, message)
 
-	file, openErr := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0600)
+	file, openErr := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
 	if openErr != nil {
 		log.Warnf("failed to open cgen log '%s': %v", logPath, openErr)
 		return
@@ -85,9 +92,9 @@ func logCgenInfoIfLogExists(cgenPath, message string) {
 	}
 }
 
-// deleteAllCgenLogs deletes all *.cgen.log files for the given bridge parameters.
-func deleteAllCgenLogs(bridgeParams []BridgeParamType) {
-	for _, bp := range bridgeParams {
-		_ = deleteCgenLog(bp.CgenName)
+// DeleteAll deletes all *.cgen.log files for the given cgen paths.
+func DeleteAll(cgenPaths []string) {
+	for _, cgenPath := range cgenPaths {
+		_ = Delete(cgenPath)
 	}
 }
@@ -4,7 +4,7 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-package stm32cubemx
+package cgenlog
 
 import (
 	"errors"
@@ -16,7 +16,7 @@ import (
 	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
 )
 
-func Test_logCgenError(t *testing.T) {
+func TestError(t *testing.T) {
 	t.Parallel()
 
 	tmpDir := t.TempDir()
@@ -24,7 +24,7 @@ func Test_logCgenError(t *testing.T) {
 	logPath := strings.TrimSuffix(cgenPath, ".yml") + ".log"
 
 	// Test writing an error
-	logCgenError(cgenPath, errors.New("test error 1"))
+	Error(cgenPath, errors.New("test error 1"))
 
 	// Verify the log file exists
 	if !utils.FileExists(logPath) {
@@ -41,12 +41,12 @@ func Test_logCgenError(t *testing.T) {
 	if !strings.Contains(content, "test error 1") {
 		t.Errorf("expected error message in log file, got: %s", content)
 	}
-	if !strings.Contains(content, "Test_logCgenError") {
+	if !strings.Contains(content, "TestError") {
 		t.Errorf("expected function name in log file, got: %s", content)
 	}
 
 	// Test appending a second error
-	logCgenError(cgenPath, errors.New("test error 2"))
+	Error(cgenPath, errors.New("test error 2"))
 
 	data, err = os.ReadFile(logPath)
 	if err != nil {
@@ -57,12 +57,12 @@ func Test_logCgenError(t *testing.T) {
 	if !strings.Contains(content, "test error 1") || !strings.Contains(content, "test error 2") {
 		t.Errorf("expected both error messages in log file, got: %s", content)
 	}
-	if !strings.Contains(content, "Test_logCgenError") {
+	if !strings.Contains(content, "TestError") {
 		t.Errorf("expected function name in log file, got: %s", content)
 	}
 }
 
-func Test_deleteCgenLog(t *testing.T) {
+func TestDelete(t *testing.T) {
 	t.Parallel()
 
 	tmpDir := t.TempDir()
@@ -70,16 +70,16 @@ func Test_deleteCgenLog(t *testing.T) {
 	logPath := strings.TrimSuffix(cgenPath, ".yml") + ".log"
 
 	// Create a log file
-	logCgenError(cgenPath, errors.New("test error"))
+	Error(cgenPath, errors.New("test error"))
 
 	if !utils.FileExists(logPath) {
 		t.Fatalf("log file was not created")
 	}
 
 	// Delete the log file
-	err := deleteCgenLog(cgenPath)
+	err := Delete(cgenPath)
 	if err != nil {
-		t.Fatalf("deleteCgenLog() returned unexpected error: %v", err)
+		t.Fatalf("Delete() returned unexpected error: %v", err)
 	}
 
 	// Verify the log file is gone
@@ -88,13 +88,13 @@ func Test_deleteCgenLog(t *testing.T) {
 	}
 
 	// Test deleting a non-existent log file (should not error)
-	err = deleteCgenLog(cgenPath)
+	err = Delete(cgenPath)
 	if err != nil {
-		t.Fatalf("deleteCgenLog() should not error for non-existent file: %v", err)
+		t.Fatalf("Delete() should not error for non-existent file: %v", err)
 	}
 }
 
-func Test_deleteAllCgenLogs(t *testing.T) {
+func TestDeleteAll(t *testing.T) {
 	t.Parallel()
 
 	tmpDir := t.TempDir()
@@ -103,8 +103,8 @@ func Test_deleteAllCgenLogs(t *testing.T) {
 	cgenPath1 := filepath.Join(tmpDir, "cgen1.yml")
 	cgenPath2 := filepath.Join(tmpDir, "cgen2.yml")
 
-	logCgenError(cgenPath1, errors.New("error 1"))
-	logCgenError(cgenPath2, errors.New("error 2"))
+	Error(cgenPath1, errors.New("error 1"))
+	Error(cgenPath2, errors.New("error 2"))
 
 	logPath1 := strings.TrimSuffix(cgenPath1, ".yml") + ".log"
 	logPath2 := strings.TrimSuffix(cgenPath2, ".yml") + ".log"
@@ -114,11 +114,7 @@ func Test_deleteAllCgenLogs(t *testing.T) {
 	}
 
 	// Delete all logs
-	bridgeParams := []BridgeParamType{
-		{CgenName: cgenPath1},
-		{CgenName: cgenPath2},
-	}
-	deleteAllCgenLogs(bridgeParams)
+	DeleteAll([]string{cgenPath1, cgenPath2})
 
 	// Verify both log files are gone
 	if utils.FileExists(logPath1) {
@@ -129,7 +125,7 @@ func Test_deleteAllCgenLogs(t *testing.T) {
 	}
 }
 
-func Test_logCgenInfoIfLogExists(t *testing.T) {
+func TestInfoIfExists(t *testing.T) {
 	t.Parallel()
 
 	tmpDir := t.TempDir()
@@ -137,14 +133,14 @@ func Test_logCgenInfoIfLogExists(t *testing.T) {
 	logPath := strings.TrimSuffix(cgenPath, ".yml") + ".log"
 
 	// No existing log: no file should be created.
-	logCgenInfoIfLogExists(cgenPath, "cgen.yml generated successfully")
+	InfoIfExists(cgenPath, "cgen.yml generated successfully")
 	if utils.FileExists(logPath) {
 		t.Fatalf("expected no log file to be created when none exists")
 	}
 
 	// Existing log: info should be appended.
-	logCgenError(cgenPath, errors.New("prior warning"))
-	logCgenInfoIfLogExists(cgenPath, "cgen.yml generated successfully")
+	Error(cgenPath, errors.New("prior warning"))
+	InfoIfExists(cgenPath, "cgen.yml generated successfully")
 
 	data, err := os.ReadFile(logPath)
 	if err != nil {
@@ -159,3 +155,20 @@ func Test_logCgenInfoIfLogExists(t *testing.T) {
 		t.Errorf("expected success info in log, got: %s", content)
 	}
 }
+
+func TestInfo(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	cgenPath := filepath.Join(tmpDir, "test.cgen.yml")
+
+	Info(cgenPath, "tool output")
+
+	data, err := os.ReadFile(GetPath(cgenPath))
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "info: tool output") {
+		t.Errorf("expected info message in log, got: %s", data)
+	}
+}
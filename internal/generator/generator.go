@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -8,14 +8,22 @@ package generator
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
 	"github.com/open-cmsis-pack/generator-bridge/internal/common"
 	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
 )
 
+// ErrGeneratorMissing is returned by Read if global.generator.yml has no entry for the generator
+var ErrGeneratorMissing = errors.New("missing in global.generator.yml")
+
 type ParamsType struct {
 	ID          string
 	DownloadURL string
+	Toolchains  []ToolchainType
+	Filters     []FiltersType
+	Groups      []GroupsType
 }
 
 type GeneratorType struct {
@@ -26,9 +34,65 @@ type GeneratorType struct {
 		Run         string `yaml:"run"`
 		Path        string `yaml:"path"`
 	} `yaml:"generator"`
+	Toolchains []ToolchainType `yaml:"toolchains"`
+	Filters    []FiltersType   `yaml:"filters"`
+	Groups     []GroupsType    `yaml:"groups"`
+}
+
+// ToolchainType overrides or adds a compiler mapping of the vendor tool project,
+// empty fields keep the built-in value of an existing compiler and layout
+type ToolchainType struct {
+	Compiler  string   `yaml:"compiler"`          // compiler of the csolution project, e.g. GCC
+	Layout    string   `yaml:"layout"`            // project layout, default "ide"
+	Toolchain string   `yaml:"toolchain"`         // toolchain name passed to the vendor tool, e.g. "STM32CubeIDE"
+	Folder    string   `yaml:"folder"`            // toolchain folder of the generated project
+	PathAdd   string   `yaml:"mxproject-path"`    // folder the .mxproject file paths are relative to, the folder of an MDK-ARM or EWARM project by default
+	Section   string   `yaml:"mxproject-section"` // .mxproject section listing the used files
+	Startup   []string `yaml:"startup"`           // startup file search path below the toolchain folder
+}
+
+// FiltersType holds the rules applied to the files, include paths and defines
+// taken from the vendor tool project, in addition to the built-in filters
+type FiltersType struct {
+	Project  string          `yaml:"project"` // csolution project name, empty for all projects
+	Files    FilterRulesType `yaml:"files"`
+	AddPaths FilterRulesType `yaml:"add-paths"`
+	Defines  FilterRulesType `yaml:"defines"`
+}
+
+// FilterRulesType lists glob patterns, or regular expressions with prefix "regex:".
+// Include rules keep entries the built-in filters remove, exclude rules drop further entries.
+type FilterRulesType struct {
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// GroupsType selects how the generated files of a project are grouped in the cgen.yml file
+type GroupsType struct {
+	Project string            `yaml:"project"` // csolution project name, empty for all projects
+	Layout  string            `yaml:"layout"`  // "flat" (default) or "tree" mirroring the generated folders
+	Rules   []GroupRuleType   `yaml:"rules"`   // rules checked before the layout, first match wins
+	Options []GroupOptionType `yaml:"options"` // defines and compiler flags of groups and files
+}
+
+// GroupRuleType places the files matching a glob pattern, or a regular expression
+// with prefix "regex:", in a group
+type GroupRuleType struct {
+	File  string `yaml:"file"`
+	Group string `yaml:"group"`
+}
+
+// GroupOptionType adds defines and compiler flags to a group, or to the files matching
+// a pattern when file is set, e.g. to suppress warnings in the HAL driver sources
+type GroupOptionType struct {
+	Group  string                 `yaml:"group"`
+	File   string                 `yaml:"file"`
+	Define []cbuild.DefineElement `yaml:"define"`
+	Misc   []cbuild.CgenMiscType  `yaml:"misc"`
 }
 
-func Read(name string, params *ParamsType) error {
+// Read looks up the generator with the given id in a global.generator.yml file
+func Read(name, id string, params *ParamsType) error {
 	var gen GeneratorType
 
 	if !utils.FileExists(name) {
@@ -41,15 +105,18 @@ func Read(name string, params *ParamsType) error {
 	if err != nil {
 		return err
 	}
+	params.Toolchains = gen.Toolchains
+	params.Filters = gen.Filters
+	params.Groups = gen.Groups
 	for _, genx := range gen.Generator {
-		if genx.ID == "CubeMX" {
+		if genx.ID == id {
 			params.ID = genx.ID
 			params.DownloadURL = genx.DownloadURL
 			break
 		}
 	}
-	if params.ID != "CubeMX" {
-		return errors.New("generator CubeMX missing in global.generator.yml")
+	if params.ID != id {
+		return fmt.Errorf("generator %s %w", id, ErrGeneratorMissing)
 	}
 	return nil
 }
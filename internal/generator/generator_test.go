@@ -9,6 +9,8 @@ package generator
 import (
 	"reflect"
 	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
 )
 
 func TestRead(t *testing.T) {
@@ -34,7 +36,7 @@ func TestRead(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			params.ID = ""
 			params.DownloadURL = ""
-			if err := Read(tt.args.name, tt.args.params); (err != nil) != tt.wantErr {
+			if err := Read(tt.args.name, "CubeMX", tt.args.params); (err != nil) != tt.wantErr {
 				t.Errorf("Read() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
 			if !reflect.DeepEqual(params, tt.want) {
@@ -43,3 +45,57 @@ func TestRead(t *testing.T) {
 		})
 	}
 }
+
+func TestReadToolchains(t *testing.T) {
+	var params ParamsType
+
+	if err := Read("../../testdata/global-toolchains.yml", "CubeMX", &params); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []ToolchainType{
+		{Compiler: "GCC", Toolchain: "STM32CubeIDE V2", Startup: []string{"Application/Startup"}},
+		{Compiler: "GCC", Layout: "cmake", Section: "PreviousUsedCmakeFiles"},
+	}
+	if !reflect.DeepEqual(params.Toolchains, want) {
+		t.Errorf("Read() toolchains = %+v, want %+v", params.Toolchains, want)
+	}
+}
+
+func TestReadFilters(t *testing.T) {
+	var params ParamsType
+
+	if err := Read("../../testdata/global-toolchains.yml", "CubeMX", &params); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []FiltersType{
+		{
+			Files:   FilterRulesType{Exclude: []string{"*_template.c"}},
+			Defines: FilterRulesType{Include: []string{"regex:^USE_"}},
+		},
+		{
+			Project:  "CM4",
+			AddPaths: FilterRulesType{Exclude: []string{"regex:/Middlewares/ST/STM32_USB_"}},
+		},
+	}
+	if !reflect.DeepEqual(params.Filters, want) {
+		t.Errorf("Read() filters = %+v, want %+v", params.Filters, want)
+	}
+}
+
+func TestReadGroups(t *testing.T) {
+	var params ParamsType
+
+	if err := Read("../../testdata/global-toolchains.yml", "CubeMX", &params); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []GroupsType{
+		{Layout: "tree"},
+		{Project: "CM4", Rules: []GroupRuleType{{File: "Middlewares/ST/STM32_USB_*", Group: "USB"}}, Options: []GroupOptionType{
+			{Group: "STM32 HAL Driver", Misc: []cbuild.CgenMiscType{{ForCompiler: "GCC", C: []string{"-Wno-unused-parameter"}}}},
+			{File: "*_hal_msp.c", Define: []cbuild.DefineElement{{NameValue: map[string]string{"MSP_TRACE": "1"}}}},
+		}},
+	}
+	if !reflect.DeepEqual(params.Groups, want) {
+		t.Errorf("Read() groups = %+v, want %+v", params.Groups, want)
+	}
+}
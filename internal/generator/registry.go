@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package generator
+
+import (
+	"errors"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+)
+
+// Generator is implemented by every vendor tool backend served by the bridge.
+// One instance is created per bridge invocation, so implementations may keep
+// the bridge parameters resolved by ReadBridgeParams for the later steps.
+type Generator interface {
+	// ID returns the generator id as used in global.generator.yml and *.cbuild-gen-idx.yml
+	ID() string
+	// ReadBridgeParams resolves the backend specific parameters from the cbuild-gen-idx.yml content
+	ReadBridgeParams(cbuildParams *cbuild.ParamsType) error
+	// WriteLaunchProject writes (or locates) the file the vendor tool is started with
+	WriteLaunchProject(workDir string) (string, error)
+	// Launch starts the vendor tool and returns its process id. A negative pid
+	// reports that the tool already finished, so no watcher daemon is started.
+	Launch(workDir, launchFile string) (int, error)
+	// Watch monitors the running vendor tool and regenerates the *.cgen.yml files on changes
+	Watch(workDir string, pid int) error
+	// WriteCgen produces the *.cgen.yml files from the vendor tool output
+	WriteCgen(workDir string) error
+}
+
+// Configurable is implemented by backends taking the toolchains, filters and groups
+// sections of global.generator.yml in one step, so they are never applied partly
+type Configurable interface {
+	Configure(params ParamsType) error
+}
+
+// ToolchainConfigurable is implemented by backends whose compiler mappings can be
+// changed from the toolchains section of global.generator.yml
+type ToolchainConfigurable interface {
+	SetToolchains(toolchains []ToolchainType) error
+}
+
+// FilterConfigurable is implemented by backends whose file, include path and
+// define filters can be changed from the filters section of global.generator.yml
+type FilterConfigurable interface {
+	SetFilters(filters []FiltersType) error
+}
+
+// GroupConfigurable is implemented by backends whose cgen.yml group layout can be
+// selected per project from the groups section of global.generator.yml
+type GroupConfigurable interface {
+	SetGroups(groups []GroupsType) error
+}
+
+// OptionConfigurable is implemented by backends with options given on the command line,
+// e.g. the project layout of the vendor tool. Options not given keep the backend default.
+type OptionConfigurable interface {
+	SetOptions(options map[string]string) error
+}
+
+// Checker is implemented by backends that can verify the committed *.cgen.yml and
+// related files against the vendor tool output without changing them
+type Checker interface {
+	// Check returns a readable report of the differences, empty if the files are up to date
+	Check(workDir string) (string, error)
+}
+
+// Stoppable is implemented by backends whose Watch loop can be ended before the vendor tool exits
+type Stoppable interface {
+	Stop()
+}
+
+// Supervisor is implemented by backends whose vendor tool keeps running while the
+// files are updated. The bridge daemon starts the tool itself instead of attaching to
+// the pid returned by Launch, so it can wait for the tool and report its exit status.
+type Supervisor interface {
+	// Command returns the command starting the vendor tool with the launch file
+	Command(workDir, launchFile string) (*exec.Cmd, error)
+	// Supervise waits for the started tool and regenerates the *.cgen.yml files on changes
+	Supervise(workDir string, cmd *exec.Cmd) error
+}
+
+// Headless is implemented by backends whose vendor tool can generate the code without
+// user interaction, e.g. in a CI job or on a build server
+type Headless interface {
+	// GenerateHeadless runs the vendor tool until the code is generated and writes the *.cgen.yml files
+	GenerateHeadless(workDir string) error
+}
+
+// Factory creates a new Generator instance
+type Factory func() Generator
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]Factory
+}{factories: make(map[string]Factory)}
+
+// Register makes a generator backend available under its id.
+// Registering the same id twice panics as it is a programming error.
+func Register(id string, factory Factory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		panic("generator: Register factory is nil for " + id)
+	}
+	if _, dup := registry.factories[id]; dup {
+		panic("generator: Register called twice for " + id)
+	}
+	registry.factories[id] = factory
+}
+
+// New creates a generator backend for the given id
+func New(id string) (Generator, error) {
+	registry.RLock()
+	factory, ok := registry.factories[id]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, errors.New("no generator backend registered for id '" + id + "', available: " + strings.Join(IDs(), ", "))
+	}
+	return factory(), nil
+}
+
+// IDs returns the sorted ids of all registered generator backends
+func IDs() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	ids := make([]string, 0, len(registry.factories))
+	for id := range registry.factories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// IsRegistered returns true if a backend exists for the given id
+func IsRegistered(id string) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	_, ok := registry.factories[id]
+	return ok
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+)
+
+type testGenerator struct{}
+
+func (g *testGenerator) ID() string                                { return "RegistryTest" }
+func (g *testGenerator) ReadBridgeParams(*cbuild.ParamsType) error { return nil }
+func (g *testGenerator) WriteLaunchProject(string) (string, error) { return "", nil }
+func (g *testGenerator) Launch(string, string) (int, error)        { return -1, nil }
+func (g *testGenerator) Watch(string, int) error                   { return nil }
+func (g *testGenerator) WriteCgen(string) error                    { return nil }
+
+func TestRegistry(t *testing.T) {
+	Register("RegistryTest", func() Generator { return &testGenerator{} })
+
+	if !IsRegistered("RegistryTest") {
+		t.Errorf("IsRegistered() RegistryTest = false, want true")
+	}
+	if IsRegistered("Unknown") {
+		t.Errorf("IsRegistered() Unknown = true, want false")
+	}
+	if got := IDs(); !reflect.DeepEqual(got, []string{"RegistryTest"}) {
+		t.Errorf("IDs() = %v, want [RegistryTest]", got)
+	}
+
+	gen, err := New("RegistryTest")
+	if err != nil || gen == nil || gen.ID() != "RegistryTest" {
+		t.Errorf("New() RegistryTest = %v, %v", gen, err)
+	}
+	if _, err := New("Unknown"); err == nil {
+		t.Errorf("New() Unknown error = nil, want error")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() twice did not panic")
+		}
+	}()
+	Register("RegistryTest", func() Generator { return &testGenerator{} })
+}
@@ -0,0 +1,523 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package infineon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+	"github.com/open-cmsis-pack/generator-bridge/internal/common"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// ID is the id of the Infineon Device Configurator in *.cbuild-gen-idx.yml
+const ID = "device-configurator"
+
+// EnvVar may point to the Device Configurator executable and overrides the discovery
+const EnvVar = "InfineonDeviceConfigurator_PATH"
+
+// featureID identifies the Device Configurator in the Infineon-Toolbox manifests
+const featureID = "com.ifx.tb.tool.deviceconfigurator"
+
+const downloadURL = "https://softwaretools.infineon.com/tools/" + featureID
+
+func init() {
+	generator.Register(ID, func() generator.Generator { return &DeviceConfigurator{} })
+}
+
+// DeviceConfigurator is the generator backend for the Infineon Device Configurator.
+// The tool edits the design.modus file next to the *.cgen.yml file and generates
+// its sources into the GeneratedSource folder which are listed in the *.cgen.yml file.
+type DeviceConfigurator struct {
+	projects []ProjectType
+}
+
+// ProjectType holds the files of one cbuild-gen context
+type ProjectType struct {
+	CgenName   string // *.cgen.yml file
+	DesignSrc  string // design.modus of the project or the device pack
+	DevicePack string
+	PackPath   string
+}
+
+// InstallType describes a found Device Configurator installation
+type InstallType struct {
+	Path    string
+	Version string
+}
+
+type toolboxManifest struct {
+	FeatureID string `json:"featureId"`
+	Version   string `json:"version"`
+	ExePath   string `json:"exePath"`
+}
+
+// pollInterval is the interval for checking design.modus while the tool runs
+var pollInterval = 500 * time.Millisecond
+
+func (d *DeviceConfigurator) ID() string {
+	return ID
+}
+
+func (d *DeviceConfigurator) ReadBridgeParams(cbuildParams *cbuild.ParamsType) error {
+	d.projects = nil
+	for _, cbuildGen := range cbuildParams.CbuildGens {
+		project, err := getProject(cbuildGen)
+		if err != nil {
+			cgenlog.Error(cbuildGen.Name, err)
+			return err
+		}
+		d.projects = append(d.projects, project)
+	}
+	if len(d.projects) == 0 {
+		return errors.New("no cbuild-gen entries found for generator " + ID)
+	}
+	return nil
+}
+
+// WriteLaunchProject copies the design.modus file next to the *.cgen.yml file
+// if it is not present yet and returns its path. The initial *.cgen.yml files are
+// written before the tool is launched.
+func (d *DeviceConfigurator) WriteLaunchProject(_ string) (string, error) {
+	if len(d.projects) == 0 {
+		return "", errors.New("no cbuild-gen entries found for generator " + ID)
+	}
+	if len(d.projects) > 1 {
+		log.Debugf("%v contexts found, launching Device Configurator for %v", len(d.projects), d.projects[0].CgenName)
+	}
+	project := d.projects[0]
+	cgenlog.DeleteAll(d.cgenPaths())
+
+	designPath, err := project.copyDesign()
+	if err != nil {
+		cgenlog.Error(project.CgenName, err)
+		return "", err
+	}
+	for _, project := range d.projects {
+		if err = project.WriteInitialCgen(); err != nil {
+			cgenlog.Error(project.CgenName, err)
+			return "", err
+		}
+	}
+	return designPath, nil
+}
+
+// copyDesign copies the design.modus file of the device pack next to the *.cgen.yml
+// file if it is not present yet
+func (p ProjectType) copyDesign() (string, error) {
+	designPath := p.DesignPath()
+	if utils.FileExists(designPath) {
+		return designPath, nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(designPath), 0750)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(p.DesignSrc)
+	if err != nil {
+		return "", fmt.Errorf("source design.modus not found: %w", err)
+	}
+	err = os.WriteFile(designPath, data, 0600)
+	if err != nil {
+		return "", err
+	}
+	log.Debugf("Copied design.modus to %v", designPath)
+	return designPath, nil
+}
+
+// Launch runs the Device Configurator for the design file and waits for it to
+// finish. Each save of the design file updates the *.cgen.yml file.
+func (d *DeviceConfigurator) Launch(workDir, designPath string) (int, error) {
+	project := d.projects[0]
+
+	install, err := FindInstall()
+	if err != nil {
+		cgenlog.Error(project.CgenName, err)
+		return -1, err
+	}
+	libraryPath, err := GetLibraryPath(project.PackPath)
+	if err != nil {
+		cgenlog.Error(project.CgenName, err)
+		return -1, err
+	}
+
+	// #nosec G204 -- install.Path is taken from the tool installation or the user environment
+	cmd := exec.Command(install.Path, "--library", libraryPath, "--design", designPath)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	log.Infof("Launching Infineon Device Configurator %v with %v", install.Version, designPath)
+	log.Debugf("Start Device Configurator as %v", cmd)
+	if err = cmd.Start(); err != nil {
+		cgenlog.Error(project.CgenName, err)
+		return -1, err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	lastModTime := modTime(designPath)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err = <-done:
+			if text := strings.TrimSpace(output.String()); text != "" {
+				cgenlog.Info(project.CgenName, "Device Configurator output:\n"+text)
+			}
+			if err != nil {
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					err = fmt.Errorf("Infineon Device Configurator failed with exit status %d", exitErr.ExitCode()) //nolint:staticcheck // tool name
+				}
+				cgenlog.Error(project.CgenName, err)
+				return -1, err
+			}
+			cgenlog.Info(project.CgenName, "Device Configurator finished with exit status 0")
+			return -1, nil
+
+		case <-ticker.C:
+			if t := modTime(designPath); !t.Equal(lastModTime) {
+				lastModTime = t
+				log.Debugf("design.modus saved, updating cgen.yml")
+				if err := d.WriteCgen(workDir); err != nil {
+					log.Debugf("cgen.yml update skipped: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// Watch is not needed, Launch waits for the tool to finish
+func (d *DeviceConfigurator) Watch(_ string, _ int) error {
+	return nil
+}
+
+// WriteCgen lists the generated sources of each context in its *.cgen.yml file
+func (d *DeviceConfigurator) WriteCgen(_ string) error {
+	for _, project := range d.projects {
+		err := project.WriteCgen()
+		if err != nil {
+			cgenlog.Error(project.CgenName, err)
+			return err
+		}
+		cgenlog.InfoIfExists(project.CgenName, "cgen.yml generated successfully")
+	}
+	return nil
+}
+
+func (d *DeviceConfigurator) cgenPaths() []string {
+	var cgenPaths []string
+	for _, project := range d.projects {
+		cgenPaths = append(cgenPaths, project.CgenName)
+	}
+	return cgenPaths
+}
+
+// DesignPath returns the design.modus file next to the *.cgen.yml file
+func (p ProjectType) DesignPath() string {
+	return filepath.Join(filepath.Dir(p.CgenName), "design.modus")
+}
+
+// WriteCgen writes the *.cgen.yml file listing the files in GeneratedSource
+func (p ProjectType) WriteCgen() error {
+	genDir := p.genDir()
+	if !utils.DirExists(genDir) {
+		return errors.New("GeneratedSource folder not found at " + genDir)
+	}
+	files, err := p.generatedFiles()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("no .c or .h files found in " + genDir)
+	}
+	return p.writeCgenFiles(files)
+}
+
+// WriteInitialCgen writes the *.cgen.yml file before Device Configurator is launched. It
+// lists the sources generated before, if any, so a *.cgen.yml file exists even if the
+// user closes the tool without generating code.
+func (p ProjectType) WriteInitialCgen() error {
+	if err := os.MkdirAll(filepath.Dir(p.CgenName), 0750); err != nil {
+		return err
+	}
+	var files []string
+	if utils.DirExists(p.genDir()) {
+		var err error
+		files, err = p.generatedFiles()
+		if err != nil {
+			return err
+		}
+	}
+	return p.writeCgenFiles(files)
+}
+
+func (p ProjectType) genDir() string {
+	return filepath.Join(filepath.Dir(p.CgenName), "GeneratedSource")
+}
+
+// generatedFiles returns the sorted .c and .h files in GeneratedSource
+func (p ProjectType) generatedFiles() ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.c", "*.h"} {
+		matches, err := filepath.Glob(filepath.Join(p.genDir(), pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (p ProjectType) writeCgenFiles(files []string) error {
+	var cgen cbuild.CgenType
+	cgen.GeneratorImport.GeneratedBy = "Infineon Device configurator"
+	group := cbuild.CgenGroupsType{Group: "ConfigTools"}
+	for _, file := range files {
+		group.Files = append(group.Files, cbuild.CgenFilesType{File: "./GeneratedSource/" + filepath.Base(file)})
+	}
+	cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, group)
+
+	return common.WriteYml(p.CgenName, &cgen)
+}
+
+// getProject collects the design file and device pack of a cbuild-gen context
+func getProject(cbuildGen cbuild.CbuildGensType) (ProjectType, error) {
+	var project ProjectType
+	buildGen := cbuildGen.CbuildGen.BuildGen
+
+	project.CgenName = cbuildGen.Name
+	if project.CgenName == "" {
+		return project, errors.New("cgen.yml path missing for project " + cbuildGen.Project)
+	}
+
+	project.DevicePack = buildGen.DevicePack
+	for _, pack := range buildGen.Packs {
+		if pack.Pack == buildGen.DevicePack {
+			project.PackPath = pack.Path
+			break
+		}
+	}
+	if project.PackPath == "" {
+		return project, errors.New("path of device pack '" + buildGen.DevicePack + "' not found in cbuild-gen.yml")
+	}
+
+	for _, component := range buildGen.Components {
+		for _, file := range component.Files {
+			if filepath.Base(file.File) == "design.modus" {
+				project.DesignSrc = file.File
+			}
+		}
+	}
+	for _, group := range buildGen.Groups {
+		for _, file := range group.Files {
+			if filepath.Base(file.File) == "design.modus" {
+				project.DesignSrc = file.File
+			}
+		}
+	}
+	if project.DesignSrc == "" && !utils.FileExists(project.DesignPath()) {
+		return project, errors.New("design.modus not found in cbuild-gen.yml of project " + cbuildGen.Project)
+	}
+
+	return project, nil
+}
+
+// GetLibraryPath returns the comma separated props.json files of the device pack
+func GetLibraryPath(packPath string) (string, error) {
+	librariesPath := filepath.Join(packPath, "Libraries")
+	if !utils.DirExists(librariesPath) {
+		return "", errors.New("Libraries folder not found at " + librariesPath) //nolint:staticcheck // folder name
+	}
+
+	var props []string
+	pdlDirs, err := filepath.Glob(filepath.Join(librariesPath, "mtb-pdl-cat*"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(pdlDirs)
+	for _, dir := range pdlDirs {
+		prop := filepath.Join(dir, "props.json")
+		if utils.FileExists(prop) {
+			props = append(props, prop)
+		} else {
+			log.Warnf("missing library file %v", prop)
+		}
+	}
+
+	deviceDB := filepath.Join(packPath, "device-info", "device-db", "props.json")
+	if utils.FileExists(deviceDB) {
+		props = append(props, deviceDB)
+	} else {
+		log.Warnf("missing library file %v", deviceDB)
+	}
+
+	if len(props) == 0 {
+		return "", errors.New("no props.json files found in mtb-pdl-cat* or device-db directories of " + packPath)
+	}
+	return strings.Join(props, ","), nil
+}
+
+// FindInstall returns the latest Device Configurator installation
+func FindInstall() (InstallType, error) {
+	if path := os.Getenv(EnvVar); path != "" {
+		path = filepath.Clean(path)
+		if !utils.FileExists(path) {
+			return InstallType{}, fmt.Errorf("%s does not exist: %s", EnvVar, path)
+		}
+		return InstallType{Path: path}, nil
+	}
+
+	install := findInstall(manifestDirs(), toolsDirs())
+	if install.Path == "" {
+		return InstallType{}, errors.New("Infineon Device Configurator not found, please download and install from " + downloadURL) //nolint:staticcheck // tool name
+	}
+	return install, nil
+}
+
+// findInstall searches the Infineon-Toolbox manifests first and then the
+// ModusToolbox tools folders for the Device Configurator with the highest version.
+func findInstall(manifestDirs, toolsDirs []string) InstallType {
+	var latest InstallType
+	for _, dir := range manifestDirs {
+		for _, install := range readManifests(dir) {
+			if latest.Path == "" || utils.CompareVersions(install.Version, latest.Version) > 0 {
+				latest = install
+			}
+		}
+		if latest.Path != "" {
+			return latest
+		}
+	}
+
+	for _, dir := range toolsDirs {
+		for _, install := range findModusToolbox(dir) {
+			if latest.Path == "" || utils.CompareVersions(install.Version, latest.Version) > 0 {
+				latest = install
+			}
+		}
+	}
+	return latest
+}
+
+func readManifests(dir string) []InstallType {
+	var installs []InstallType
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304 -- manifest file of the tool installation
+		if err != nil {
+			continue
+		}
+		var manifest toolboxManifest
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			log.Debugf("ignoring %v: %v", file, err)
+			continue
+		}
+		if !strings.EqualFold(manifest.FeatureID, featureID) {
+			continue
+		}
+		exePath := filepath.FromSlash(manifest.ExePath)
+		if !utils.FileExists(exePath) {
+			log.Debugf("exePath not found or invalid: %v", exePath)
+			continue
+		}
+		installs = append(installs, InstallType{Path: exePath, Version: manifest.Version})
+	}
+	return installs
+}
+
+// findModusToolbox searches <dir>/tools_<version>/device-configurator
+func findModusToolbox(dir string) []InstallType {
+	var installs []InstallType
+
+	toolsFolders, err := filepath.Glob(filepath.Join(dir, "tools_*"))
+	if err != nil {
+		return nil
+	}
+	for _, toolsFolder := range toolsFolders {
+		exePath := filepath.Join(toolsFolder, "device-configurator", executableName())
+		if utils.FileExists(exePath) {
+			version := strings.TrimPrefix(filepath.Base(toolsFolder), "tools_")
+			installs = append(installs, InstallType{Path: exePath, Version: version})
+		}
+	}
+	return installs
+}
+
+func executableName() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "device-configurator.exe"
+	case "darwin":
+		return filepath.Join("device-configurator.app", "Contents", "MacOS", "device-configurator")
+	default:
+		return "device-configurator"
+	}
+}
+
+func manifestDirs() []string {
+	var dirs []string
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("USERPROFILE"); dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "AppData", "Local", "Infineon_Technologies_AG", "Infineon-Toolbox"))
+		}
+		if dir := os.Getenv("ALLUSERSPROFILE"); dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "Infineon_Technologies_AG", "Infineon-Toolbox"))
+		}
+	default:
+		if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, ".local", "share", "Infineon_Technologies_AG", "Infineon-Toolbox"))
+		}
+	}
+	return dirs
+}
+
+func toolsDirs() []string {
+	var dirs []string
+	if dir := os.Getenv("CY_TOOLS_PATHS"); dir != "" {
+		dirs = append(dirs, filepath.Dir(filepath.Clean(dir)))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "ModusToolbox"))
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		dirs = append(dirs, "/Applications/ModusToolbox")
+	case "linux":
+		dirs = append(dirs, "/opt/Tools/ModusToolbox")
+	}
+	return dirs
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package infineon
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+	"gopkg.in/yaml.v3"
+)
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func manifest(featureID, version, exePath string) string {
+	return `{"featureId": "` + featureID + `", "version": "` + version + `", "exePath": "` + filepath.ToSlash(exePath) + `"}`
+}
+
+func Test_findInstall(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	userDir := filepath.Join(dir, "user")
+	allDir := filepath.Join(dir, "all")
+	mtbDir := filepath.Join(dir, "ModusToolbox")
+
+	exe4 := filepath.Join(dir, "dc4", executableName())
+	exe5 := filepath.Join(dir, "dc5", executableName())
+	writeFile(t, exe4, "", 0o600)
+	writeFile(t, exe5, "", 0o600)
+	writeFile(t, filepath.Join(allDir, "dc4.json"), manifest(featureID, "4.20.0.1", exe4), 0o600)
+	writeFile(t, filepath.Join(allDir, "dc5.json"), manifest(featureID, "5.10.0.2", exe5), 0o600)
+	writeFile(t, filepath.Join(allDir, "missing.json"), manifest(featureID, "9.0.0", filepath.Join(dir, "missing")), 0o600)
+	writeFile(t, filepath.Join(allDir, "other.json"), manifest("com.ifx.tb.tool.other", "10.0.0", exe4), 0o600)
+	writeFile(t, filepath.Join(allDir, "broken.json"), "{", 0o600)
+
+	mtb33 := filepath.Join(mtbDir, "tools_3.3", "device-configurator", executableName())
+	mtb36 := filepath.Join(mtbDir, "tools_3.6", "device-configurator", executableName())
+	writeFile(t, mtb33, "", 0o600)
+	writeFile(t, mtb36, "", 0o600)
+
+	// the all-users manifests are used when no per-user manifest exists
+	got := findInstall([]string{userDir, allDir}, []string{mtbDir})
+	if want := (InstallType{Path: exe5, Version: "5.10.0.2"}); got != want {
+		t.Errorf("findInstall() = %+v, want %+v", got, want)
+	}
+
+	// per-user manifests take precedence
+	writeFile(t, filepath.Join(userDir, "dc4.json"), manifest(featureID, "4.20.0.1", exe4), 0o600)
+	got = findInstall([]string{userDir, allDir}, []string{mtbDir})
+	if want := (InstallType{Path: exe4, Version: "4.20.0.1"}); got != want {
+		t.Errorf("findInstall() user = %+v, want %+v", got, want)
+	}
+
+	// ModusToolbox installations are the fallback
+	got = findInstall([]string{filepath.Join(dir, "none")}, []string{filepath.Join(dir, "none"), mtbDir})
+	if want := (InstallType{Path: mtb36, Version: "3.6"}); got != want {
+		t.Errorf("findInstall() ModusToolbox = %+v, want %+v", got, want)
+	}
+
+	got = findInstall(nil, []string{filepath.Join(dir, "none")})
+	if got.Path != "" {
+		t.Errorf("findInstall() empty = %+v, want none", got)
+	}
+}
+
+func TestGetLibraryPath(t *testing.T) {
+	t.Parallel()
+
+	packPath := t.TempDir()
+	if _, err := GetLibraryPath(packPath); err == nil {
+		t.Errorf("GetLibraryPath() error = nil, want missing Libraries folder")
+	}
+
+	writeFile(t, filepath.Join(packPath, "Libraries", "mtb-pdl-cat1", "props.json"), "{}", 0o600)
+	writeFile(t, filepath.Join(packPath, "Libraries", "mtb-pdl-cat2", "readme.md"), "", 0o600)
+	writeFile(t, filepath.Join(packPath, "device-info", "device-db", "props.json"), "{}", 0o600)
+
+	got, err := GetLibraryPath(packPath)
+	if err != nil {
+		t.Fatalf("GetLibraryPath() error = %v", err)
+	}
+	want := filepath.Join(packPath, "Libraries", "mtb-pdl-cat1", "props.json") + "," + filepath.Join(packPath, "device-info", "device-db", "props.json")
+	if got != want {
+		t.Errorf("GetLibraryPath() = %v, want %v", got, want)
+	}
+}
+
+func TestDeviceConfigurator_Launch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub executable is a shell script")
+	}
+
+	tmpDir := t.TempDir()
+	packPath := filepath.Join(tmpDir, "pack")
+	designSrc := filepath.Join(tmpDir, "project", "design.modus")
+	cgenPath := filepath.Join(tmpDir, "project", "gen", "project.cgen.yml")
+	genDir := filepath.Join(filepath.Dir(cgenPath), "GeneratedSource")
+	writeFile(t, filepath.Join(packPath, "Libraries", "mtb-pdl-cat1", "props.json"), "{}", 0o600)
+	writeFile(t, designSrc, "<Design/>", 0o600)
+
+	var cbuildGen cbuild.CbuildGenType
+	err := yaml.Unmarshal([]byte(`build-gen:
+  device-pack: Infineon::PSOC_DFP@1.0.0
+  packs:
+    - pack: ARM::CMSIS@6.1.0
+      path: /cmsis
+    - pack: Infineon::PSOC_DFP@1.0.0
+      path: `+packPath+`
+  groups:
+    - group: Config
+      files:
+        - file: `+designSrc+`
+`), &cbuildGen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := filepath.Join(tmpDir, "device-configurator")
+	t.Setenv(EnvVar, stub)
+
+	var dc DeviceConfigurator
+	err = dc.ReadBridgeParams(&cbuild.ParamsType{CbuildGens: []cbuild.CbuildGensType{{Name: cgenPath, Project: "project", CbuildGen: cbuildGen}}})
+	if err != nil {
+		t.Fatalf("ReadBridgeParams() error = %v", err)
+	}
+	designPath, err := dc.WriteLaunchProject(tmpDir)
+	if err != nil {
+		t.Fatalf("WriteLaunchProject() error = %v", err)
+	}
+	if designPath != filepath.Join(filepath.Dir(cgenPath), "design.modus") {
+		t.Errorf("WriteLaunchProject() = %v", designPath)
+	}
+	// the initial cgen.yml exists before the tool generates any sources
+	initialCgen, err := os.ReadFile(cgenPath)
+	if err != nil {
+		t.Fatalf("WriteLaunchProject() did not write %v: %v", cgenPath, err)
+	}
+	if want := "generator-import:\n  generated-by: Infineon Device configurator\n  groups:\n    - group: ConfigTools\n"; string(initialCgen) != want {
+		t.Errorf("WriteLaunchProject() initial cgen.yml = %q, want %q", initialCgen, want)
+	}
+
+	// successful run generating sources
+	pollInterval = 10 * time.Millisecond
+	writeFile(t, stub, "#!/bin/sh\necho \"args: $*\"\nmkdir -p "+genDir+"\ntouch "+genDir+"/cycfg.c "+genDir+"/cycfg.h\n", 0o700) // #nosec G306
+	pid, err := dc.Launch(tmpDir, designPath)
+	if err != nil || pid != -1 {
+		t.Fatalf("Launch() = %v, %v, want -1, nil", pid, err)
+	}
+	if err := dc.WriteCgen(tmpDir); err != nil {
+		t.Fatalf("WriteCgen() error = %v", err)
+	}
+	data, _ := os.ReadFile(cgenlog.GetPath(cgenPath))
+	if !strings.Contains(string(data), "--library "+packPath) || !strings.Contains(string(data), "--design "+designPath) || !strings.Contains(string(data), "exit status 0") {
+		t.Errorf("cgen.log misses output or exit status: %s", data)
+	}
+	var cgen cbuild.CgenType
+	data, _ = os.ReadFile(cgenPath)
+	if err := yaml.Unmarshal(data, &cgen); err != nil {
+		t.Fatal(err)
+	}
+	groups := cgen.GeneratorImport.Groups
+	if cgen.GeneratorImport.GeneratedBy != "Infineon Device configurator" || len(groups) != 1 || groups[0].Group != "ConfigTools" ||
+		len(groups[0].Files) != 2 || groups[0].Files[0].File != "./GeneratedSource/cycfg.c" || groups[0].Files[1].File != "./GeneratedSource/cycfg.h" {
+		t.Errorf("unexpected cgen.yml: %s", data)
+	}
+
+	// failing run
+	writeFile(t, stub, "#!/bin/sh\necho no license >&2\nexit 2\n", 0o700) // #nosec G306
+	_, err = dc.Launch(tmpDir, designPath)
+	if err == nil || !strings.Contains(err.Error(), "exit status 2") {
+		t.Errorf("Launch() error = %v, want exit status 2", err)
+	}
+	data, _ = os.ReadFile(cgenlog.GetPath(cgenPath))
+	if !strings.Contains(string(data), "no license") {
+		t.Errorf("cgen.log misses output: %s", data)
+	}
+
+	// no generated sources
+	_ = os.RemoveAll(genDir)
+	if err := dc.WriteCgen(tmpDir); err == nil {
+		t.Errorf("WriteCgen() error = nil, want missing GeneratedSource")
+	}
+
+	// missing installation
+	t.Setenv(EnvVar, filepath.Join(tmpDir, "missing"))
+	if _, err := dc.Launch(tmpDir, designPath); err == nil {
+		t.Errorf("Launch() error = nil, want missing installation")
+	}
+}
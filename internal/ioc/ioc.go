@@ -0,0 +1,337 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package ioc reads STM32CubeMX project files (*.ioc) into a typed model.
+package ioc
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ioc is the content of a STM32CubeMX project file
+type Ioc struct {
+	Properties     []Property // all properties in file order
+	Mcu            McuType
+	Contexts       []ContextType // ordered by index, empty for single context projects
+	IPs            map[string]IPType
+	Pins           map[string]PinType
+	RCC            RCCType
+	ProjectManager ProjectManagerType
+	UserConstants  []UserConstantType
+
+	sections map[string]map[string]string
+}
+
+// McuType holds the Mcu.* settings
+type McuType struct {
+	Family   string
+	Name     string
+	UserName string
+	Package  string
+	CPN      string
+	IPs      []string // Mcu.IP<n> ordered by n
+	Pins     []string // Mcu.Pin<n> ordered by n
+	Params   map[string]string
+}
+
+// ContextType is one core or security context of a multi context project
+type ContextType struct {
+	Index  int
+	Name   string
+	IPs    []string // IP names without the ':I' (inherited) marker, nil if <context>.IPs is missing
+	Params map[string]string
+}
+
+// IPType holds the settings of a peripheral instance (<ip>.*)
+type IPType struct {
+	Name         string
+	VirtualMode  string
+	IPParameters []string
+	Params       map[string]string
+}
+
+// PinType holds the settings of a pin (<pin>.*)
+type PinType struct {
+	Name           string
+	Signal         string
+	Label          string
+	Mode           string
+	Locked         bool
+	GPIOParameters []string
+	GPIO           map[string]string // GPIO_* parameters like GPIO_PuPd or GPIO_Speed
+	Params         map[string]string
+}
+
+// RCCType holds the clock settings (RCC.*)
+type RCCType struct {
+	Params map[string]string
+}
+
+// ProjectManagerType holds the code generation settings (ProjectManager.*)
+type ProjectManagerType struct {
+	ProjectName     string
+	MainLocation    string
+	TargetToolchain string
+	FirmwarePackage string
+	CoupleFile      bool
+	Params          map[string]string
+}
+
+// UserConstantType is one entry of Mcu.UserConstants
+type UserConstantType struct {
+	Name  string
+	Value string
+}
+
+// Read reads and parses the STM32CubeMX project file
+func Read(path string) (*Ioc, error) {
+	f, err := os.Open(path) // #nosec G304 -- path of the STM32CubeMX project
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses the content of a STM32CubeMX project file
+func Parse(r io.Reader) (*Ioc, error) {
+	properties, err := ReadProperties(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(properties), nil
+}
+
+// New builds the typed model from the properties of a STM32CubeMX project file.
+// The section of a key is the part before its first '.', the rest of the key
+// is the parameter name within the section.
+func New(properties []Property) *Ioc {
+	i := &Ioc{
+		Properties: properties,
+		IPs:        make(map[string]IPType),
+		Pins:       make(map[string]PinType),
+		sections:   make(map[string]map[string]string),
+	}
+	for _, property := range properties {
+		section, param, found := strings.Cut(property.Key, ".")
+		if !found {
+			continue
+		}
+		if i.sections[section] == nil {
+			i.sections[section] = make(map[string]string)
+		}
+		i.sections[section][param] = property.Value
+	}
+
+	i.readMcu()
+	i.readContexts()
+	i.readIPs()
+	i.readPins()
+	i.RCC.Params = i.sections["RCC"]
+	i.readProjectManager()
+	return i
+}
+
+// Get returns the value of the full key, e.g. "ProjectManager.MainLocation"
+func (i *Ioc) Get(key string) string {
+	section, param, _ := strings.Cut(key, ".")
+	return i.sections[section][param]
+}
+
+// Section returns the parameters of a section, the keys are without the section prefix
+func (i *Ioc) Section(name string) map[string]string {
+	return i.sections[name]
+}
+
+// Context returns the context of the given name
+func (i *Ioc) Context(name string) (ContextType, bool) {
+	for _, context := range i.Contexts {
+		if context.Name == name {
+			return context, true
+		}
+	}
+	return ContextType{}, false
+}
+
+// UserConstant returns the value of a user constant
+func (i *Ioc) UserConstant(name string) (string, bool) {
+	for _, constant := range i.UserConstants {
+		if constant.Name == name {
+			return constant.Value, true
+		}
+	}
+	return "", false
+}
+
+// HasIP returns true if the IP is assigned to the context
+func (c ContextType) HasIP(ip string) bool {
+	for _, name := range c.IPs {
+		if name == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// Frequency returns RCC.<name>Freq_Value, e.g. Frequency("SPI1") for RCC.SPI1Freq_Value
+func (r RCCType) Frequency(name string) (string, bool) {
+	value, ok := r.Params[name+"Freq_Value"]
+	return value, ok
+}
+
+// FrequencyNames returns the sorted names of all RCC.<name>Freq_Value entries
+func (r RCCType) FrequencyNames() []string {
+	var names []string
+	for key := range r.Params {
+		if name, found := strings.CutSuffix(key, "Freq_Value"); found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (i *Ioc) readMcu() {
+	params := i.sections["Mcu"]
+	i.Mcu = McuType{
+		Family:   params["Family"],
+		Name:     params["Name"],
+		UserName: params["UserName"],
+		Package:  params["Package"],
+		CPN:      params["CPN"],
+		IPs:      indexedValues(params, "IP"),
+		Pins:     indexedValues(params, "Pin"),
+		Params:   params,
+	}
+
+	for _, constant := range strings.Split(params["UserConstants"], ";") {
+		name, value, _ := strings.Cut(constant, ",")
+		name = strings.TrimSpace(name)
+		if name != "" {
+			i.UserConstants = append(i.UserConstants, UserConstantType{Name: name, Value: strings.TrimSpace(value)})
+		}
+	}
+}
+
+func (i *Ioc) readContexts() {
+	params := i.sections["Mcu"]
+	for _, index := range indexes(params, "Context") {
+		name := params["Context"+strconv.Itoa(index)]
+		context := ContextType{Index: index, Name: name, Params: i.sections[name]}
+		if ips, ok := context.Params["IPs"]; ok {
+			context.IPs = []string{}
+			for _, ip := range strings.Split(ips, ",") {
+				ip, _, _ = strings.Cut(ip, ":")
+				if ip = strings.TrimSpace(ip); ip != "" {
+					context.IPs = append(context.IPs, ip)
+				}
+			}
+		}
+		i.Contexts = append(i.Contexts, context)
+	}
+}
+
+func (i *Ioc) readIPs() {
+	for _, name := range i.Mcu.IPs {
+		params := i.sections[name]
+		ip := IPType{Name: name, Params: params, IPParameters: splitList(params["IPParameters"])}
+		var keys []string
+		for key := range params {
+			if strings.HasPrefix(key, "VirtualMode") {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			ip.VirtualMode = params[keys[0]]
+		}
+		i.IPs[name] = ip
+	}
+}
+
+func (i *Ioc) readPins() {
+	names := make(map[string]bool)
+	for _, name := range i.Mcu.Pins {
+		names[name] = true
+	}
+	for name, params := range i.sections {
+		if _, ok := params["Signal"]; ok {
+			names[name] = true
+		}
+	}
+
+	for name := range names {
+		params := i.sections[name]
+		pin := PinType{
+			Name:           name,
+			Signal:         params["Signal"],
+			Label:          params["GPIO_Label"],
+			Mode:           params["Mode"],
+			Locked:         params["Locked"] == "true",
+			GPIOParameters: splitList(params["GPIOParameters"]),
+			GPIO:           make(map[string]string),
+			Params:         params,
+		}
+		for key, value := range params {
+			if strings.HasPrefix(key, "GPIO_") {
+				pin.GPIO[key] = value
+			}
+		}
+		i.Pins[name] = pin
+	}
+}
+
+func (i *Ioc) readProjectManager() {
+	params := i.sections["ProjectManager"]
+	i.ProjectManager = ProjectManagerType{
+		ProjectName:     params["ProjectName"],
+		MainLocation:    params["MainLocation"],
+		TargetToolchain: params["TargetToolchain"],
+		FirmwarePackage: params["FirmwarePackage"],
+		CoupleFile:      params["CoupleFile"] == "true",
+		Params:          params,
+	}
+}
+
+// indexes returns the sorted n of all <prefix><n> keys
+func indexes(params map[string]string, prefix string) []int {
+	var result []int
+	for key := range params {
+		if number, found := strings.CutPrefix(key, prefix); found {
+			if n, err := strconv.Atoi(number); err == nil {
+				result = append(result, n)
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// indexedValues returns the values of all <prefix><n> keys ordered by n
+func indexedValues(params map[string]string, prefix string) []string {
+	var values []string
+	for _, n := range indexes(params, prefix) {
+		values = append(values, params[prefix+strconv.Itoa(n)])
+	}
+	return values
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
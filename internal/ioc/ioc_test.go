@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testIoc = `#MicroXplorer Configuration settings - do not modify
+CortexM33NS.IPs=CORTEX_M33_NS\:I,GPDMA1,RCC,USART1
+CortexM33S.IPs=CORTEX_M33_S\:I,GPDMA1\:I,SPI2\:I,OCTOSPI2\:I
+Mcu.CPN=STM32U585AII6Q
+Mcu.Context0=CortexM33S
+Mcu.Context1=CortexM33NS
+Mcu.ContextNb=2
+Mcu.Family=STM32U5
+Mcu.IP0=USART1
+Mcu.IP1=SPI2
+Mcu.IP10=OCTOSPI2
+Mcu.IP2=RCC
+Mcu.IPNb=4
+Mcu.Name=STM32U585AIIxQ
+Mcu.Package=UFBGA169
+Mcu.Pin0=PA9
+Mcu.Pin1=PC14-OSC32_IN (PC14)
+Mcu.Pin2=VP_SYS_VS_Systick
+Mcu.UserConstants=SPI2_PERIPH_CLOCK_FREQ,16000000;MY_CONST, 1
+Mcu.UserName=STM32U585AIIxQ
+PA9.GPIOParameters=GPIO_Label,GPIO_PuPd
+PA9.GPIO_Label=VCP_TX [ST-LINK]
+PA9.GPIO_PuPd=GPIO_PULLUP
+PA9.Locked=true
+PA9.Mode=Asynchronous
+PA9.Signal=USART1_TX
+PB5.Signal=SPI2_MOSI
+PC14-OSC32_IN\ (PC14).Mode=LSE-External-Oscillator
+PC14-OSC32_IN\ (PC14).Signal=RCC_OSC32_IN
+ProjectManager.CoupleFile=true
+ProjectManager.FirmwarePackage=STM32Cube FW_U5 V1.4.0
+ProjectManager.MainLocation=Core/Src
+ProjectManager.ProjectName=test
+ProjectManager.TargetToolchain=CMake
+RCC.SPI2Freq_Value=160000000
+RCC.I2C1Freq_Value=80000000
+RCC.PLLM=1
+SPI2.BaudRatePrescaler=SPI_BAUDRATEPRESCALER_4
+SPI2.IPParameters=VirtualType,Mode,BaudRatePrescaler
+SPI2.VirtualType=VM_MASTER
+USART1.VirtualMode-Asynchronous=VM_ASYNC
+USART1.IPParameters=VirtualMode-Asynchronous
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	i, err := Parse(strings.NewReader(testIoc))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if i.Mcu.Family != "STM32U5" || i.Mcu.Name != "STM32U585AIIxQ" || i.Mcu.Package != "UFBGA169" || i.Mcu.CPN != "STM32U585AII6Q" || i.Mcu.UserName != "STM32U585AIIxQ" {
+		t.Errorf("Mcu = %+v", i.Mcu)
+	}
+	if want := []string{"USART1", "SPI2", "RCC", "OCTOSPI2"}; !reflect.DeepEqual(i.Mcu.IPs, want) {
+		t.Errorf("Mcu.IPs = %v, want %v", i.Mcu.IPs, want)
+	}
+	if want := []string{"PA9", "PC14-OSC32_IN (PC14)", "VP_SYS_VS_Systick"}; !reflect.DeepEqual(i.Mcu.Pins, want) {
+		t.Errorf("Mcu.Pins = %v, want %v", i.Mcu.Pins, want)
+	}
+
+	if len(i.Contexts) != 2 || i.Contexts[0].Name != "CortexM33S" || i.Contexts[1].Name != "CortexM33NS" || i.Contexts[1].Index != 1 {
+		t.Fatalf("Contexts = %+v", i.Contexts)
+	}
+	if want := []string{"CORTEX_M33_S", "GPDMA1", "SPI2", "OCTOSPI2"}; !reflect.DeepEqual(i.Contexts[0].IPs, want) {
+		t.Errorf("Contexts[0].IPs = %v, want %v", i.Contexts[0].IPs, want)
+	}
+	secure, ok := i.Context("CortexM33S")
+	if !ok || !secure.HasIP("SPI2") || secure.HasIP("USART1") {
+		t.Errorf("Context(CortexM33S) = %+v, %v", secure, ok)
+	}
+	if _, ok := i.Context("CortexM4"); ok {
+		t.Errorf("Context(CortexM4) found")
+	}
+
+	if ip := i.IPs["USART1"]; ip.VirtualMode != "VM_ASYNC" || !reflect.DeepEqual(ip.IPParameters, []string{"VirtualMode-Asynchronous"}) {
+		t.Errorf("IPs[USART1] = %+v", ip)
+	}
+	if ip := i.IPs["SPI2"]; ip.VirtualMode != "" || ip.Params["BaudRatePrescaler"] != "SPI_BAUDRATEPRESCALER_4" {
+		t.Errorf("IPs[SPI2] = %+v", ip)
+	}
+
+	pin := i.Pins["PA9"]
+	if pin.Signal != "USART1_TX" || pin.Label != "VCP_TX [ST-LINK]" || pin.Mode != "Asynchronous" || !pin.Locked ||
+		!reflect.DeepEqual(pin.GPIOParameters, []string{"GPIO_Label", "GPIO_PuPd"}) ||
+		!reflect.DeepEqual(pin.GPIO, map[string]string{"GPIO_Label": "VCP_TX [ST-LINK]", "GPIO_PuPd": "GPIO_PULLUP"}) {
+		t.Errorf("Pins[PA9] = %+v", pin)
+	}
+	if pin := i.Pins["PC14-OSC32_IN (PC14)"]; pin.Signal != "RCC_OSC32_IN" || pin.Mode != "LSE-External-Oscillator" {
+		t.Errorf("Pins[PC14] = %+v", pin)
+	}
+	if _, ok := i.Pins["PB5"]; !ok {
+		t.Errorf("pin PB5 with signal not found")
+	}
+	if _, ok := i.Pins["VP_SYS_VS_Systick"]; !ok {
+		t.Errorf("virtual pin not found")
+	}
+	if len(i.Pins) != 4 {
+		t.Errorf("Pins = %v", i.Pins)
+	}
+
+	if freq, ok := i.RCC.Frequency("SPI2"); !ok || freq != "160000000" {
+		t.Errorf("RCC.Frequency(SPI2) = %v, %v", freq, ok)
+	}
+	if want := []string{"I2C1", "SPI2"}; !reflect.DeepEqual(i.RCC.FrequencyNames(), want) {
+		t.Errorf("RCC.FrequencyNames() = %v, want %v", i.RCC.FrequencyNames(), want)
+	}
+
+	pm := i.ProjectManager
+	if pm.ProjectName != "test" || pm.MainLocation != "Core/Src" || pm.TargetToolchain != "CMake" || pm.FirmwarePackage != "STM32Cube FW_U5 V1.4.0" || !pm.CoupleFile {
+		t.Errorf("ProjectManager = %+v", pm)
+	}
+
+	want := []UserConstantType{{"SPI2_PERIPH_CLOCK_FREQ", "16000000"}, {"MY_CONST", "1"}}
+	if !reflect.DeepEqual(i.UserConstants, want) {
+		t.Errorf("UserConstants = %v, want %v", i.UserConstants, want)
+	}
+	if value, ok := i.UserConstant("MY_CONST"); !ok || value != "1" {
+		t.Errorf("UserConstant(MY_CONST) = %v, %v", value, ok)
+	}
+
+	if got := i.Get("ProjectManager.MainLocation"); got != "Core/Src" {
+		t.Errorf("Get() = %v", got)
+	}
+	if got := i.Section("RCC")["PLLM"]; got != "1" {
+		t.Errorf("Section(RCC) = %v", i.Section("RCC"))
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	i, err := Read("../../testdata/stm32cubemx/test.ioc")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if i.Mcu.Family != "STM32U5" || len(i.Contexts) != 0 || len(i.Properties) != 2 {
+		t.Errorf("Read() = %+v", i)
+	}
+	if got := i.Get("PA10.GPIOParameters"); got != "GPIO_Label" {
+		t.Errorf("Get(PA10.GPIOParameters) = %v", got)
+	}
+
+	if _, err := Read("xxx"); err == nil {
+		t.Errorf("Read() error = nil for missing file")
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ioc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Property is one unescaped key value pair of a Java properties file
+type Property struct {
+	Key   string
+	Value string
+}
+
+// ReadProperties reads a Java properties file as written by STM32CubeMX.
+// Comments and blank lines are skipped, continuation lines are joined and
+// escape sequences in keys and values are resolved. The file order is kept.
+func ReadProperties(r io.Reader) ([]Property, error) {
+	var properties []Property
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var logical string
+	continued := false
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if !continued {
+			if line == "" || line[0] == '#' || line[0] == '!' {
+				continue
+			}
+			logical = ""
+		}
+		if endsWithEscape(line) {
+			logical += line[:len(line)-1]
+			continued = true
+			continue
+		}
+		logical += line
+		continued = false
+
+		property, err := parseLine(logical)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		properties = append(properties, property)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if continued {
+		property, err := parseLine(logical)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// endsWithEscape reports whether the line ends with an odd number of backslashes
+func endsWithEscape(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// parseLine splits a logical line at the first unescaped '=', ':' or white space
+func parseLine(line string) (Property, error) {
+	keyEnd := len(line)
+	valueStart := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			keyEnd = i
+			valueStart = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[valueStart:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	key, err := Unescape(line[:keyEnd])
+	if err != nil {
+		return Property{}, err
+	}
+	value, err := Unescape(rest)
+	if err != nil {
+		return Property{}, err
+	}
+	return Property{Key: key, Value: value}, nil
+}
+
+// Unescape resolves the escape sequences of a Java properties key or value
+func Unescape(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(s) {
+			break
+		}
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 >= len(s) {
+				return "", fmt.Errorf("malformed \\uxxxx encoding in '%s'", s)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uxxxx encoding in '%s'", s)
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
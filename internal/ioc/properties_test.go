@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadProperties(t *testing.T) {
+	t.Parallel()
+
+	content := "#MicroXplorer Configuration settings - do not modify\n" +
+		"! another comment\n" +
+		"\n" +
+		"Mcu.Family=STM32U5\n" +
+		"CortexM33S.IPs=CORTEX_M33_S\\:I,GPDMA1\\:I\n" +
+		"PC14-OSC32_IN\\ (PC14).Mode=LSE-External-Oscillator\n" +
+		"Mcu.UserConstants=A,1;B,x\\=y\n" +
+		"Dma.Request0=SPI1_RX\n" +
+		"Dma.SPI1_RX.0.Direction=DMA_PERIPH_TO_MEMORY\n" +
+		"NVIC.SysTick_IRQn=true\\:15\\:0\\:false\\:false\\:true\\:false\\:true\\:false\n" +
+		"PA10.GPIO_Label=Label \\#1 = [Arduino]\n" +
+		"Key\\:With\\=Separators=value\n" +
+		"   Indented   :   spaced value  \n" +
+		"Continued=first, \\\n" +
+		"    second\n" +
+		"Unicode=\\u00B5s\\tTab\n" +
+		"Empty=\n" +
+		"NoValue\n"
+
+	got, err := ReadProperties(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ReadProperties() error = %v", err)
+	}
+	want := []Property{
+		{"Mcu.Family", "STM32U5"},
+		{"CortexM33S.IPs", "CORTEX_M33_S:I,GPDMA1:I"},
+		{"PC14-OSC32_IN (PC14).Mode", "LSE-External-Oscillator"},
+		{"Mcu.UserConstants", "A,1;B,x=y"},
+		{"Dma.Request0", "SPI1_RX"},
+		{"Dma.SPI1_RX.0.Direction", "DMA_PERIPH_TO_MEMORY"},
+		{"NVIC.SysTick_IRQn", "true:15:0:false:false:true:false:true:false"},
+		{"PA10.GPIO_Label", "Label #1 = [Arduino]"},
+		{"Key:With=Separators", "value"},
+		{"Indented", "spaced value  "},
+		{"Continued", "first, second"},
+		{"Unicode", "µs\tTab"},
+		{"Empty", ""},
+		{"NoValue", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadProperties() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "plain", false},
+		{"a\\:b\\=c\\#d\\!e", "a:b=c#d!e", false},
+		{"back\\\\slash", "back\\slash", false},
+		{"\\u0041\\u00e9", "Aé", false},
+		{"\\u00", "", true},
+		{"\\uxyzw", "", true},
+		{"trailing\\", "trailing", false},
+	}
+	for _, tt := range tests {
+		got, err := Unescape(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unescape(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unescape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package mcuxpresso
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// ID is the id of MCUXpresso Config Tools in *.cbuild-gen-idx.yml
+const ID = "MCUXpressoConfig"
+
+// EnvVar may point to the MCUXpresso Config Tools executable and overrides the discovery
+const EnvVar = "MCUXpressoConfigTools_PATH"
+
+func init() {
+	generator.Register(ID, func() generator.Generator { return &ConfigTools{} })
+}
+
+// ConfigTools is the generator backend for MCUXpresso Config Tools (v16 and newer).
+// The tool reads the cbuild-gen-idx.yml file itself and writes the *.cgen.yml files,
+// so the bridge only launches it, waits for it and checks the result.
+type ConfigTools struct {
+	idxFile   string
+	cgenPaths []string
+}
+
+// InstallType describes a found MCUXpresso Config Tools installation
+type InstallType struct {
+	Path    string // executable or macOS application bundle
+	Folder  string // working directory for the tool
+	Version string
+}
+
+// applicationDirs are searched for *.desktop files on Linux
+var applicationDirs = []string{"/usr/share/applications", "/usr/local/share/applications"}
+
+func (c *ConfigTools) ID() string {
+	return ID
+}
+
+func (c *ConfigTools) ReadBridgeParams(cbuildParams *cbuild.ParamsType) error {
+	c.idxFile = cbuildParams.IdxFile
+	c.cgenPaths = nil
+	for _, cbuildGen := range cbuildParams.CbuildGens {
+		c.cgenPaths = append(c.cgenPaths, cbuildGen.Name)
+	}
+	return nil
+}
+
+// WriteLaunchProject returns the cbuild-gen-idx.yml file, the tool creates its project from it
+func (c *ConfigTools) WriteLaunchProject(_ string) (string, error) {
+	if c.idxFile == "" {
+		return "", errors.New("cbuild-gen-idx.yml file not set")
+	}
+	return filepath.Abs(c.idxFile)
+}
+
+// Launch runs MCUXpresso Config Tools in generator mode and waits for it to finish.
+// Exit status and output of the tool are written to the *.cgen.log files.
+func (c *ConfigTools) Launch(_, launchFile string) (int, error) {
+	cgenlog.DeleteAll(c.cgenPaths)
+
+	install, err := FindInstall()
+	if err != nil {
+		c.logError(err)
+		return -1, err
+	}
+	log.Infof("Launching MCUXpresso Config Tools %v with %v", install.Version, launchFile)
+
+	cmd := Command(install, "-CreateFromProject", launchFile, "-OpencmsisGeneratorCgen")
+	log.Debugf("Start MCUXpresso Config Tools as %v", cmd)
+	output, err := cmd.CombinedOutput()
+	c.logOutput(output)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = fmt.Errorf("MCUXpresso Config Tools failed with exit status %d", exitErr.ExitCode())
+		}
+		c.logError(err)
+		return -1, err
+	}
+	c.logInfo("MCUXpresso Config Tools finished with exit status 0")
+
+	return -1, nil
+}
+
+// Watch is not needed, Launch waits for the tool to finish
+func (c *ConfigTools) Watch(_ string, _ int) error {
+	return nil
+}
+
+// WriteCgen checks that MCUXpresso Config Tools has written all *.cgen.yml files
+func (c *ConfigTools) WriteCgen(_ string) error {
+	var missing []string
+	for _, cgenPath := range c.cgenPaths {
+		if !utils.FileExists(cgenPath) {
+			err := errors.New("MCUXpresso Config Tools did not generate " + cgenPath)
+			cgenlog.Error(cgenPath, err)
+			missing = append(missing, cgenPath)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("missing generated file(s): " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (c *ConfigTools) logOutput(output []byte) {
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return
+	}
+	log.Debugf("MCUXpresso Config Tools output:\n%v", text)
+	c.logInfo("MCUXpresso Config Tools output:\n" + text)
+}
+
+func (c *ConfigTools) logInfo(message string) {
+	for _, cgenPath := range c.cgenPaths {
+		cgenlog.Info(cgenPath, message)
+	}
+}
+
+func (c *ConfigTools) logError(err error) {
+	for _, cgenPath := range c.cgenPaths {
+		cgenlog.Error(cgenPath, err)
+	}
+}
+
+// Command creates the command running the installation with the given arguments
+func Command(install InstallType, args ...string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" && strings.HasSuffix(install.Path, ".app") {
+		openArgs := append([]string{"-W", "-n", install.Path, "--args"}, args...)
+		cmd = exec.Command("open", openArgs...)
+	} else {
+		// #nosec G204 -- install.Path is taken from the tool installation or the user environment
+		cmd = exec.Command(install.Path, args...)
+	}
+	cmd.Dir = install.Folder
+	return cmd
+}
+
+// FindInstall returns the latest MCUXpresso Config Tools installation
+func FindInstall() (InstallType, error) {
+	if path := os.Getenv(EnvVar); path != "" {
+		path = filepath.Clean(path)
+		if !utils.FileExists(path) && !utils.DirExists(path) {
+			return InstallType{}, fmt.Errorf("%s does not exist: %s", EnvVar, path)
+		}
+		return InstallType{Path: path, Folder: filepath.Dir(path)}, nil
+	}
+
+	var install InstallType
+	var err error
+	switch runtime.GOOS {
+	case "windows":
+		install, err = findInstallWindows()
+	case "darwin":
+		install, err = findInstallDarwin()
+	default:
+		install, err = findInstallLinux(applicationDirs)
+	}
+	if err != nil {
+		return InstallType{}, err
+	}
+	if install.Path == "" {
+		return InstallType{}, errors.New("MCUXpresso Config Tools were not found, set " + EnvVar + " or install v16 or newer")
+	}
+	return install, nil
+}
+
+// findInstallLinux searches the *.desktop entries of MCUXpresso Config Tools
+// and returns the one with the highest version.
+func findInstallLinux(dirs []string) (InstallType, error) {
+	var latest InstallType
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			if entry.IsDir() || !strings.Contains(name, "mcuxpresso-config") || !strings.HasSuffix(name, ".desktop") {
+				continue
+			}
+			install, err := readDesktopEntry(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				log.Debugf("ignoring %v: %v", entry.Name(), err)
+				continue
+			}
+			if install.Version == "" || install.Path == "" {
+				continue
+			}
+			if latest.Path == "" || utils.CompareVersions(install.Version, latest.Version) > 0 {
+				latest = install
+			}
+		}
+	}
+	return latest, nil
+}
+
+func readDesktopEntry(path string) (InstallType, error) {
+	var install InstallType
+
+	file, err := os.Open(path)
+	if err != nil {
+		return install, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Version":
+			install.Version = strings.TrimSpace(value)
+		case "Exec":
+			install.Path = execPath(value)
+		case "Path":
+			install.Folder = strings.TrimSpace(value)
+		}
+	}
+	if install.Folder == "" && install.Path != "" {
+		install.Folder = filepath.Dir(install.Path)
+	}
+	return install, scanner.Err()
+}
+
+// execPath returns the program of a desktop entry Exec line without arguments
+func execPath(exec string) string {
+	exec = strings.TrimSpace(exec)
+	if strings.HasPrefix(exec, "\"") {
+		if end := strings.Index(exec[1:], "\""); end >= 0 {
+			return exec[1 : end+1]
+		}
+	}
+	if fields := strings.Fields(exec); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func findInstallWindows() (InstallType, error) {
+	const key = `HKEY_CLASSES_ROOT\NXP Semiconductors.MCUXpresso Config Tools.mex\shell\open\command`
+	output, err := exec.Command("reg", "query", key, "/ve").Output()
+	if err != nil {
+		return InstallType{}, nil // not installed
+	}
+	return InstallType{Path: parseRegQuery(string(output))}, nil
+}
+
+// parseRegQuery extracts the quoted executable from the default value of a "reg query" output
+func parseRegQuery(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "REG_") {
+			continue
+		}
+		parts := strings.Split(line, "\"")
+		if len(parts) >= 2 {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
+func findInstallDarwin() (InstallType, error) {
+	query := "kMDItemKind == 'Application' && kMDItemCFBundleIdentifier == 'com.nxp.*MCUXpresso*Config*'"
+	output, err := exec.Command("mdfind", query).Output()
+	if err != nil {
+		return InstallType{}, fmt.Errorf("mdfind failed: %w", err)
+	}
+
+	var latest InstallType
+	for _, app := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if app == "" {
+			continue
+		}
+		version, err := exec.Command("defaults", "read", filepath.Join(app, "Contents", "Info.plist"), "CFBundleShortVersionString").Output() // #nosec G204 -- app is returned by mdfind
+		if err != nil {
+			continue
+		}
+		install := InstallType{Path: app, Folder: filepath.Dir(app), Version: strings.TrimSpace(string(version))}
+		if latest.Path == "" || utils.CompareVersions(install.Version, latest.Version) > 0 {
+			latest = install
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package mcuxpresso
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+)
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func Test_findInstallLinux(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "mcuxpresso-config-16.desktop"), "[Desktop Entry]\nVersion=16.0\nExec=/opt/nxp/mcux16/bin/tools\nPath=/opt/nxp/mcux16/bin\n", 0o600)
+	writeFile(t, filepath.Join(dir, "mcuxpresso-config-25.desktop"), "[Desktop Entry]\nVersion=25.06\nExec=\"/opt/nxp/mcux 25/bin/tools\" %f\n", 0o600)
+	writeFile(t, filepath.Join(dir, "mcuxpresso-config-9.desktop"), "[Desktop Entry]\nVersion=9.0\nExec=/opt/nxp/mcux9/bin/tools\n", 0o600)
+	writeFile(t, filepath.Join(dir, "other.desktop"), "[Desktop Entry]\nVersion=99.0\nExec=/usr/bin/other\n", 0o600)
+
+	got, err := findInstallLinux([]string{filepath.Join(dir, "missing"), dir})
+	if err != nil {
+		t.Fatalf("findInstallLinux() error = %v", err)
+	}
+	want := InstallType{Path: "/opt/nxp/mcux 25/bin/tools", Folder: "/opt/nxp/mcux 25/bin", Version: "25.06"}
+	if got != want {
+		t.Errorf("findInstallLinux() = %+v, want %+v", got, want)
+	}
+
+	got, _ = findInstallLinux([]string{filepath.Join(dir, "missing")})
+	if got.Path != "" {
+		t.Errorf("findInstallLinux() empty = %+v, want none", got)
+	}
+}
+
+func Test_parseRegQuery(t *testing.T) {
+	t.Parallel()
+
+	output := "\r\nHKEY_CLASSES_ROOT\\NXP Semiconductors.MCUXpresso Config Tools.mex\\shell\\open\\command\r\n" +
+		"    (Default)    REG_SZ    \"C:\\nxp\\MCUX_CFG_v25.06\\bin\\tools.exe\" \"%1\"\r\n"
+	if got := parseRegQuery(output); got != "C:\\nxp\\MCUX_CFG_v25.06\\bin\\tools.exe" {
+		t.Errorf("parseRegQuery() = %v", got)
+	}
+	if got := parseRegQuery("ERROR"); got != "" {
+		t.Errorf("parseRegQuery() error output = %v, want empty", got)
+	}
+}
+
+func TestConfigTools_Launch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub executable is a shell script")
+	}
+
+	tmpDir := t.TempDir()
+	cgenPath := filepath.Join(tmpDir, "project.cgen.yml")
+	idxPath := filepath.Join(tmpDir, "test.cbuild-gen-idx.yml")
+	writeFile(t, idxPath, "build-gen-idx:\n", 0o600)
+
+	stub := filepath.Join(tmpDir, "tools")
+	t.Setenv(EnvVar, stub)
+
+	var cfg ConfigTools
+	if err := cfg.ReadBridgeParams(&cbuild.ParamsType{IdxFile: idxPath, CbuildGens: []cbuild.CbuildGensType{{Name: cgenPath}}}); err != nil {
+		t.Fatalf("ReadBridgeParams() error = %v", err)
+	}
+	launchFile, err := cfg.WriteLaunchProject(tmpDir)
+	if err != nil {
+		t.Fatalf("WriteLaunchProject() error = %v", err)
+	}
+
+	// successful run writing the cgen.yml file
+	writeFile(t, stub, "#!/bin/sh\necho \"args: $*\"\necho generator-import: > "+cgenPath+"\n", 0o700) // #nosec G306
+	pid, err := cfg.Launch(tmpDir, launchFile)
+	if err != nil || pid != -1 {
+		t.Fatalf("Launch() = %v, %v, want -1, nil", pid, err)
+	}
+	if err := cfg.WriteCgen(tmpDir); err != nil {
+		t.Errorf("WriteCgen() error = %v", err)
+	}
+	data, _ := os.ReadFile(cgenlog.GetPath(cgenPath))
+	if !strings.Contains(string(data), "-CreateFromProject "+launchFile+" -OpencmsisGeneratorCgen") || !strings.Contains(string(data), "exit status 0") {
+		t.Errorf("cgen.log misses output or exit status: %s", data)
+	}
+
+	// failing run without cgen.yml file
+	_ = os.Remove(cgenPath)
+	writeFile(t, stub, "#!/bin/sh\necho license expired >&2\nexit 3\n", 0o700) // #nosec G306
+	_, err = cfg.Launch(tmpDir, launchFile)
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("Launch() error = %v, want exit status 3", err)
+	}
+	if err := cfg.WriteCgen(tmpDir); err == nil {
+		t.Errorf("WriteCgen() error = nil, want missing cgen.yml")
+	}
+	data, _ = os.ReadFile(cgenlog.GetPath(cgenPath))
+	if !strings.Contains(string(data), "license expired") || !strings.Contains(string(data), "exit status 3") {
+		t.Errorf("cgen.log misses output or exit status: %s", data)
+	}
+
+	// missing installation
+	t.Setenv(EnvVar, filepath.Join(tmpDir, "missing"))
+	if _, err := cfg.Launch(tmpDir, launchFile); err == nil {
+		t.Errorf("Launch() error = nil, want missing installation")
+	}
+}
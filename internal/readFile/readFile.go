@@ -44,8 +44,11 @@ func Process(inFile, inFile2, outPath string) error {
 		if err != nil {
 			return err
 		}
+		if len(params) == 0 {
+			return errors.New("no CubeMX project found in " + genIdxFile)
+		}
 
-		_, err = stm32cubemx.WriteProjectFile(outPath, params[0])
+		_, err = stm32cubemx.WriteProjectFile(stm32cubemx.DefaultConfig(), outPath, params[0])
 		if err != nil {
 			return err
 		}
@@ -60,7 +63,10 @@ func Process(inFile, inFile2, outPath string) error {
 	}
 
 	if mxprojectFile != "" {
-		mxprojectAll, _ := stm32cubemx.IniReader(mxprojectFile, params)
+		if len(params) == 0 {
+			return errors.New("cbuild-gen-idx.yml file required to read " + mxprojectFile)
+		}
+		mxprojectAll, _ := stm32cubemx.IniReader(stm32cubemx.DefaultConfig(), mxprojectFile, params)
 
 		if params[0].BoardName == "" && params[0].Device == "" {
 			params[0].BoardName = "Test Board"
@@ -92,13 +98,13 @@ func Process(inFile, inFile2, outPath string) error {
 			return err
 		}
 
-		err = stm32cubemx.ReadContexts(filepath.Join(workDir, "STM32CubeMX", "STM32CubeMX.ioc"), params)
-		// err = stm32cubemx.ReadContexts(filepath.Join(workDir, "STM32CubeMX.ioc"), params)
+		err = stm32cubemx.ReadContexts(stm32cubemx.DefaultConfig(), filepath.Join(workDir, "STM32CubeMX", "STM32CubeMX.ioc"), params)
+		// err = stm32cubemx.ReadContexts(stm32cubemx.DefaultConfig(), filepath.Join(workDir, "STM32CubeMX.ioc"), params)
 		if err != nil {
 			return err
 		}
 
-		err = stm32cubemx.WriteCgenYml(outPath, mxprojectAll, params)
+		err = stm32cubemx.WriteCgenYml(stm32cubemx.DefaultConfig(), outPath, mxprojectAll, params)
 		if err != nil {
 			return err
 		}
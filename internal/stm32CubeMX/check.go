@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
+)
+
+// iocTimeTolerance allows for the .ioc file being written shortly after the .mxproject
+// file, e.g. by a git checkout, without reporting it as changed after code generation
+const iocTimeTolerance = 2 * time.Second
+
+// Check regenerates the *.cgen.yml and MX_Device.h files into a temporary folder and
+// compares them to the files on disk. The returned report is empty if they match.
+func (c *CubeMX) Check(workDir string) (string, error) {
+	cubeIocPath := getCubeMxFolder(workDir)
+	iocprojectPath := filepath.Join(cubeIocPath, "STM32CubeMX.ioc")
+	mxprojectPath := filepath.Join(cubeIocPath, ".mxproject")
+	for _, file := range []string{iocprojectPath, mxprojectPath} {
+		if !utils.FileExists(file) {
+			return "", errors.New("file not found: " + file + ", generate the code with CubeMX first")
+		}
+	}
+
+	var report strings.Builder
+	stale, err := isIocNewer(iocprojectPath, mxprojectPath)
+	if err != nil {
+		return "", err
+	}
+	if stale {
+		report.WriteString(iocprojectPath + " is newer than " + mxprojectPath + ", generate the code with CubeMX\n")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cbridge-check-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// the outputs are redirected, the paths inside the cgen.yml files stay relative to workDir
+	bridgeParams := make([]BridgeParamType, len(c.bridgeParams))
+	copy(bridgeParams, c.bridgeParams)
+	for i := range bridgeParams {
+		cgenDir := filepath.Join(tmpDir, "cgen", strconv.Itoa(i))
+		if err = os.MkdirAll(cgenDir, 0750); err != nil {
+			return "", err
+		}
+		bridgeParams[i].CgenName = filepath.Join(cgenDir, filepath.Base(c.bridgeParams[i].CgenName))
+	}
+
+	c.mutex.Lock()
+	cfg := c.getConfig()
+	c.mutex.Unlock()
+	mxproject, err := IniReader(cfg, mxprojectPath, bridgeParams)
+	if err != nil {
+		return "", err
+	}
+	cfgRoot := filepath.Join(filepath.Dir(cubeIocPath), "MX_Device")
+	tmpCfgRoot := filepath.Join(tmpDir, "MX_Device")
+	if err = readContexts(cfg, iocprojectPath, bridgeParams, tmpCfgRoot); err != nil {
+		return "", err
+	}
+	if err = WriteCgenYml(cfg, workDir, mxproject, bridgeParams); err != nil {
+		return "", err
+	}
+
+	for i, bp := range bridgeParams {
+		if err = compareGenerated(&report, c.bridgeParams[i].CgenName, bp.CgenName); err != nil {
+			return "", err
+		}
+		cfgFile := filepath.Join(bp.CubeContextFolder, "MX_Device.h")
+		if err = compareGenerated(&report, filepath.Join(cfgRoot, cfgFile), filepath.Join(tmpCfgRoot, cfgFile)); err != nil {
+			return "", err
+		}
+	}
+	return report.String(), nil
+}
+
+// isIocNewer checks if the .ioc file was changed after the code was generated
+func isIocNewer(iocprojectPath, mxprojectPath string) (bool, error) {
+	iocInfo, err := os.Stat(iocprojectPath)
+	if err != nil {
+		return false, err
+	}
+	mxprojectInfo, err := os.Stat(mxprojectPath)
+	if err != nil {
+		return false, err
+	}
+	return iocInfo.ModTime().After(mxprojectInfo.ModTime().Add(iocTimeTolerance)), nil
+}
+
+// compareGenerated adds a unified diff to the report if the file on disk differs
+// from the regenerated one. A file not regenerated is not compared.
+func compareGenerated(report *strings.Builder, diskFile, genFile string) error {
+	genData, err := os.ReadFile(genFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	diskData, err := os.ReadFile(diskFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			report.WriteString(diskFile + " is missing\n")
+			return nil
+		}
+		return err
+	}
+
+	diskText := normalizeGenerated(string(diskData))
+	genText := normalizeGenerated(string(genData))
+	if diskText == genText {
+		log.Debugf("%v is up to date", diskFile)
+		return nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(diskText),
+		B:        difflib.SplitLines(genText),
+		FromFile: diskFile,
+		ToFile:   diskFile + " (regenerated)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	report.WriteString(diff)
+	return nil
+}
+
+// normalizeGenerated removes the line endings and the generation date, which differ
+// without a change of the content
+func normalizeGenerated(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, " * Date        : ") {
+			return strings.Join(append(lines[:i:i], lines[i+1:]...), "\n")
+		}
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Check(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	if err := os.CopyFS(workDir, os.DirFS("../../testdata/testExamples/STM32F7/STM32CubeMX/STM32F746NGHx")); err != nil {
+		t.Fatalf("os.CopyFS() error = %v", err)
+	}
+	cgenName := filepath.Join(workDir, "CubeMX.cgen.yml")
+	cubeMX := &CubeMX{bridgeParams: []BridgeParamType{{Device: "STM32F746NGHx", ProjectName: "CubeMX", Compiler: "AC6", CgenName: cgenName}}}
+	if err := cubeMX.WriteCgen(workDir); err != nil {
+		t.Fatalf("WriteCgen() error = %v", err)
+	}
+
+	report, err := cubeMX.Check(workDir)
+	if err != nil || report != "" {
+		t.Fatalf("Check() = %q, %v, want up to date", report, err)
+	}
+
+	cgen, err := os.ReadFile(cgenName)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if err = os.WriteFile(cgenName, []byte(strings.Replace(string(cgen), "group: CubeMX", "group: Edited", 1)), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	mxDevice := filepath.Join(workDir, "MX_Device", "MX_Device.h")
+	if err = os.Remove(mxDevice); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	iocFile := filepath.Join(workDir, "STM32CubeMX", "STM32CubeMX.ioc")
+	later := time.Now().Add(time.Hour)
+	if err = os.Chtimes(iocFile, later, later); err != nil {
+		t.Fatalf("os.Chtimes() error = %v", err)
+	}
+
+	report, err = cubeMX.Check(workDir)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	for _, want := range []string{
+		iocFile + " is newer than",
+		"--- " + cgenName + "\n",
+		"-    - group: Edited\n+    - group: CubeMX\n",
+		mxDevice + " is missing",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Check() report misses %q:\n%v", want, report)
+		}
+	}
+	if _, err = os.Stat(mxDevice); err == nil {
+		t.Errorf("Check() wrote %v", mxDevice)
+	}
+}
+
+func Test_normalizeGenerated(t *testing.T) {
+	t.Parallel()
+
+	got := normalizeGenerated("/*\r\n * File Name   : MX_Device.h\r\n * Date        : 17/10/2026 10:00:00\r\n */\r\n")
+	want := "/*\n * File Name   : MX_Device.h\n */\n"
+	if got != want {
+		t.Errorf("normalizeGenerated() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+// Config holds the settings of a CubeMX backend from global.generator.yml and the command
+// line options. It is not changed once built, a new Config replaces it under the lock of
+// the backend.
+type Config struct {
+	toolchains []toolchainType    // built-in table with the toolchains section applied
+	filters    []filterPolicyType // filters section
+	groups     []groupPolicyType  // groups section
+	layout     ProjectLayout      // CubeMX project layout
+	mxDevice   MXDeviceSource     // source of the MX_Device.h values
+	middleware MiddlewareSource   // source of the middlewares
+}
+
+// defaultConfig holds the built-in settings
+var defaultConfig = &Config{
+	toolchains: defaultToolchains,
+	layout:     LayoutIDE,
+	mxDevice:   MXDeviceFromIoc,
+	middleware: MiddlewareFromCubeMX,
+}
+
+// DefaultConfig returns the built-in settings, used without global.generator.yml and options
+func DefaultConfig() *Config {
+	return defaultConfig
+}
+
+// Configure applies the toolchains, filters and groups sections of global.generator.yml.
+// All sections are checked first and replace the settings in one step, the previous
+// settings are kept on error.
+func (c *CubeMX) Configure(params generator.ParamsType) error {
+	toolchains, err := newToolchains(params.Toolchains)
+	if err != nil {
+		return err
+	}
+	filters, err := newFilterPolicies(params.Filters)
+	if err != nil {
+		return err
+	}
+	groups, err := newGroupPolicies(params.Groups)
+	if err != nil {
+		return err
+	}
+	c.setConfig(func(config *Config) {
+		config.toolchains, config.filters, config.groups = toolchains, filters, groups
+	})
+	return nil
+}
+
+// getConfig returns the settings of the backend, the caller holds c.mutex
+func (c *CubeMX) getConfig() *Config {
+	if c.config == nil {
+		return defaultConfig
+	}
+	return c.config
+}
+
+// setConfig replaces the settings of the backend with a changed copy
+func (c *CubeMX) setConfig(change func(config *Config)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	config := *c.getConfig()
+	change(&config)
+	c.config = &config
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+// Test_Configure is meant for go test -race, the daemon reload configures the backend
+// while the watch loop regenerates the files
+func Test_Configure(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cubeIocPath := filepath.Join(workDir, "STM32CubeMX")
+	if err := os.CopyFS(cubeIocPath, os.DirFS("../../testdata/testExamples/STM32F7/STM32CubeMX/STM32F746NGHx/STM32CubeMX")); err != nil {
+		t.Fatalf("os.CopyFS() error = %v", err)
+	}
+	cubeMX := &CubeMX{bridgeParams: []BridgeParamType{{
+		Device: "STM32F746NGHx", ProjectName: "CubeMX", ProjectType: "single-core", Compiler: "AC6",
+		CgenName: filepath.Join(workDir, "CubeMX.cgen.yml"),
+	}}}
+
+	params := generator.ParamsType{
+		Toolchains: []generator.ToolchainType{{Compiler: "AC6", Toolchain: "MDK-ARM V5.32"}},
+		Filters:    []generator.FiltersType{{Files: generator.FilterRulesType{Exclude: []string{"*_template.c"}}}},
+		Groups:     []generator.GroupsType{{Layout: "tree"}},
+	}
+	done := make(chan error)
+	go func() {
+		var err error
+		for range 3 {
+			err = cubeMX.update(workDir, filepath.Join(cubeIocPath, "STM32CubeMX.ioc"), filepath.Join(cubeIocPath, ".mxproject"))
+			if err != nil {
+				break
+			}
+		}
+		done <- err
+	}()
+	for updating := true; updating; {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("update() error = %v", err)
+			}
+			updating = false
+		default:
+			if err := cubeMX.Configure(params); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			if err := cubeMX.Configure(generator.ParamsType{}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+		}
+	}
+
+	if err := cubeMX.Configure(params); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	// an invalid section leaves all sections unchanged
+	invalid := params
+	invalid.Toolchains = nil
+	invalid.Groups = []generator.GroupsType{{Layout: "folders"}}
+	if err := cubeMX.Configure(invalid); err == nil {
+		t.Errorf("Configure() error = nil for unknown group layout")
+	}
+	cfg := cubeMX.getConfig()
+	if toolchain, err := GetToolchain(cfg, "AC6"); err != nil || toolchain != "MDK-ARM V5.32" {
+		t.Errorf("Configure() error changed the toolchain to %v, %v", toolchain, err)
+	}
+	if policy := cfg.getGroupPolicy("CubeMX"); policy.layout != GroupsTree {
+		t.Errorf("Configure() error changed the group layout to %v", policy.layout)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	log "github.com/sirupsen/logrus"
+)
+
+// filterRuleType is a glob pattern or, with prefix "regex:", a regular expression
+type filterRuleType struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// filterRulesType holds the compiled include and exclude rules of one kind of entries
+type filterRulesType struct {
+	include []filterRuleType
+	exclude []filterRuleType
+}
+
+// filterPolicyType holds the filter rules of a project, "" applies to all projects
+type filterPolicyType struct {
+	project  string
+	files    filterRulesType
+	addPaths filterRulesType
+	defines  filterRulesType
+}
+
+// SetFilters applies the filters section of global.generator.yml, the previous rules
+// are kept on error
+func (c *CubeMX) SetFilters(filters []generator.FiltersType) error {
+	policies, err := newFilterPolicies(filters)
+	if err != nil {
+		return err
+	}
+	c.setConfig(func(config *Config) { config.filters = policies })
+	return nil
+}
+
+// newFilterPolicies compiles the filter rules of the projects
+func newFilterPolicies(filters []generator.FiltersType) ([]filterPolicyType, error) {
+	var policies []filterPolicyType
+	for _, entry := range filters {
+		policy := filterPolicyType{project: entry.Project}
+		var err error
+		policy.files, err = newFilterRules(entry.Files)
+		if err != nil {
+			return nil, err
+		}
+		policy.addPaths, err = newFilterRules(entry.AddPaths)
+		if err != nil {
+			return nil, err
+		}
+		policy.defines, err = newFilterRules(entry.Defines)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// getFilterPolicy returns the filter rules of a project, without entry the built-in
+// filters are used only
+func (cfg *Config) getFilterPolicy(project string) filterPolicyType {
+	var policy filterPolicyType
+	for _, entry := range cfg.filters {
+		if entry.project == project {
+			return entry
+		}
+		if entry.project == "" {
+			policy = entry
+		}
+	}
+	return policy
+}
+
+func newFilterRules(rules generator.FilterRulesType) (filterRulesType, error) {
+	var filterRules filterRulesType
+	var err error
+	filterRules.include, err = newFilterRuleList(rules.Include)
+	if err != nil {
+		return filterRules, err
+	}
+	filterRules.exclude, err = newFilterRuleList(rules.Exclude)
+	return filterRules, err
+}
+
+func newFilterRuleList(patterns []string) ([]filterRuleType, error) {
+	var rules []filterRuleType
+	for _, pattern := range patterns {
+		rule := filterRuleType{pattern: pattern}
+		if expr, ok := strings.CutPrefix(pattern, "regex:"); ok {
+			regex, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, errors.New("invalid filter rule '" + pattern + "' in global.generator.yml: " + err.Error())
+			}
+			rule.regex = regex
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.New("invalid filter rule '" + pattern + "' in global.generator.yml: " + err.Error())
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// match checks a file, path or define against the rule. A glob matches the
+// entry or any of its trailing path segments, e.g. "*_template.c" or "Src/main.c".
+func (r filterRuleType) match(value string) bool {
+	value = strings.ReplaceAll(value, "\\", "/")
+	if r.regex != nil {
+		return r.regex.MatchString(value)
+	}
+	segments := strings.Split(value, "/")
+	for i := range segments {
+		if ok, _ := path.Match(r.pattern, strings.Join(segments[i:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the rule decision for the value, decided is false if no rule matches
+func (r filterRulesType) apply(kind, value string) (filtered, decided bool) {
+	for _, rule := range r.include {
+		if rule.match(value) {
+			log.Debugf("keeping %v %v: include rule '%v'", kind, value, rule.pattern)
+			return false, true
+		}
+	}
+	for _, rule := range r.exclude {
+		if rule.match(value) {
+			log.Debugf("ignoring %v %v: exclude rule '%v'", kind, value, rule.pattern)
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// filterFile returns true if a file is not added to the cgen.yml file
+func (p filterPolicyType) filterFile(file string) bool {
+	if filtered, decided := p.files.apply("file", file); decided {
+		return filtered
+	}
+	return FilterFile(file)
+}
+
+// filterAddPath returns true if an include path is not added to the cgen.yml file
+func (p filterPolicyType) filterAddPath(addPath string) bool {
+	if filtered, decided := p.addPaths.apply("include path", addPath); decided {
+		return filtered
+	}
+	return FilterFile(addPath)
+}
+
+// parseDefine splits a .mxproject define like ParseDefine, the define rules are checked first
+func (p filterPolicyType) parseDefine(define string) (cbuild.DefineElement, bool) {
+	name, value, _ := strings.Cut(define, "=")
+	name = strings.TrimSpace(name)
+	filtered, decided := p.defines.apply("define", name)
+	if !decided {
+		filtered = FilterDefine(name)
+		if filtered {
+			log.Debugf("ignoring define %v: no valid C identifier", name)
+		}
+	}
+	if filtered {
+		return cbuild.DefineElement{}, false
+	}
+	return cbuild.DefineElement{NameValue: map[string]string{name: strings.TrimSpace(value)}}, true
+}
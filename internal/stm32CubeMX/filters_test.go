@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+func Test_SetFilters(t *testing.T) {
+	t.Parallel()
+
+	cubeMX := &CubeMX{}
+	err := cubeMX.SetFilters([]generator.FiltersType{{
+		Files: generator.FilterRulesType{
+			Include: []string{"Templates/startup_*.s"},
+			// a glob matches trailing path segments, "Src" does not drop the files below Src folders
+			Exclude: []string{"*_template.c", "regex:^\\.\\./Middlewares/.*/Demo/", "Src"},
+		},
+		AddPaths: generator.FilterRulesType{
+			Include: []string{"regex:/Drivers/CMSIS/Include$"},
+			Exclude: []string{"./Core/Inc"},
+		},
+		Defines: generator.FilterRulesType{
+			Exclude: []string{"USE_FULL_*"},
+		},
+	}, {
+		Project: "CM4",
+		Files:   generator.FilterRulesType{Exclude: []string{"main.c"}},
+	}})
+	if err != nil {
+		t.Fatalf("SetFilters() error = %v", err)
+	}
+	cfg := cubeMX.getConfig()
+
+	files := map[string]bool{
+		"../Drivers/STM32F4xx_HAL_Driver/Src/stm32f4xx_hal_msp_template.c": true,
+		"..\\Middlewares\\Third_Party\\Demo\\demo.c":                       true,
+		"../Middlewares/Third_Party/FatFs/src/ff.c":                        false,
+		"Drivers/CMSIS/Device/ST/Templates/startup_stm32f4xx.s":            false,
+		"Drivers/CMSIS/Device/ST/Templates/system_stm32f4xx.c":             true,
+		"../Core/Src/system_stm32f4xx.c":                                   true,
+		"../Core/Src/main.c":                                               false,
+	}
+	filters := cfg.getFilterPolicy("CM7")
+	for file, want := range files {
+		if got := filters.filterFile(file); got != want {
+			t.Errorf("filterFile(%v) = %v, want %v", file, got, want)
+		}
+	}
+	// the rules of a project replace the rules for all projects
+	filters = cfg.getFilterPolicy("CM4")
+	if !filters.filterFile("../Core/Src/main.c") || filters.filterFile("stm32f4xx_hal_msp_template.c") {
+		t.Errorf("filterFile() does not apply the rules of project CM4")
+	}
+	filters = cfg.getFilterPolicy("CM7")
+
+	addPaths := map[string]bool{
+		"/work/STM32CubeMX/Drivers/CMSIS/Include": false,
+		"./Core/Inc":                          true,
+		"./Drivers/STM32F4xx_HAL_Driver/Inc":  false,
+		"./Drivers/CMSIS/Device/ST/Templates": true,
+	}
+	for addPath, want := range addPaths {
+		if got := filters.filterAddPath(addPath); got != want {
+			t.Errorf("filterAddPath(%v) = %v, want %v", addPath, got, want)
+		}
+	}
+
+	defines := map[string]bool{
+		"USE_FULL_ASSERT":  false,
+		"USE_HAL_DRIVER":   true,
+		"HSE_VALUE=800000": true,
+		"1BAD":             false,
+	}
+	for define, want := range defines {
+		if _, got := filters.parseDefine(define); got != want {
+			t.Errorf("parseDefine(%v) = %v, want %v", define, got, want)
+		}
+	}
+
+	for _, pattern := range []string{"regex:(", "[a-"} {
+		if err := cubeMX.SetFilters([]generator.FiltersType{{Files: generator.FilterRulesType{Exclude: []string{pattern}}}}); err == nil {
+			t.Errorf("SetFilters(%v) error = nil", pattern)
+		}
+	}
+	if !cubeMX.getConfig().getFilterPolicy("").filterFile("stm32f4xx_hal_msp_template.c") {
+		t.Errorf("SetFilters() error replaced the previous rules")
+	}
+	if DefaultConfig().getFilterPolicy("").filterFile("stm32f4xx_hal_msp_template.c") {
+		t.Errorf("SetFilters() changed the built-in settings")
+	}
+}
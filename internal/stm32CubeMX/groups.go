@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+// GroupLayout selects how the generated files are grouped in the cgen.yml file
+type GroupLayout string
+
+const (
+	// GroupsFlat places the HAL driver files in "STM32 HAL Driver" and all others in "CubeMX"
+	GroupsFlat GroupLayout = "flat"
+	// GroupsTree mirrors the CubeMX folders, e.g. "Core", "Drivers/HAL" or "Middlewares/FreeRTOS"
+	GroupsTree GroupLayout = "tree"
+)
+
+// groupRuleType places the files matching the rule in a group
+type groupRuleType struct {
+	rule  filterRuleType
+	group string
+}
+
+// groupOptionType adds defines and compiler flags to a group or to the matching files
+type groupOptionType struct {
+	group  string
+	file   *filterRuleType
+	define []cbuild.DefineElement
+	misc   []cbuild.CgenMiscType
+}
+
+// groupPolicyType is the group layout of a project, "" applies to all projects
+type groupPolicyType struct {
+	project string
+	layout  GroupLayout
+	rules   []groupRuleType
+	options []groupOptionType
+}
+
+// SetGroups applies the groups section of global.generator.yml, the previous layouts
+// are kept on error
+func (c *CubeMX) SetGroups(groups []generator.GroupsType) error {
+	policies, err := newGroupPolicies(groups)
+	if err != nil {
+		return err
+	}
+	c.setConfig(func(config *Config) { config.groups = policies })
+	return nil
+}
+
+// newGroupPolicies checks the group layouts, rules and options of the projects
+func newGroupPolicies(groups []generator.GroupsType) ([]groupPolicyType, error) {
+	var policies []groupPolicyType
+	for _, entry := range groups {
+		policy := groupPolicyType{project: entry.Project, layout: GroupsFlat}
+		switch GroupLayout(entry.Layout) {
+		case "", GroupsFlat:
+		case GroupsTree:
+			policy.layout = GroupsTree
+		default:
+			return nil, errors.New("unknown group layout '" + entry.Layout + "' in global.generator.yml, use 'flat' or 'tree'")
+		}
+		for _, rule := range entry.Rules {
+			if rule.Group == "" {
+				return nil, errors.New("group rule '" + rule.File + "' without group in global.generator.yml")
+			}
+			filterRules, err := newFilterRuleList([]string{rule.File})
+			if err != nil {
+				return nil, err
+			}
+			policy.rules = append(policy.rules, groupRuleType{rule: filterRules[0], group: rule.Group})
+		}
+		for _, entry := range entry.Options {
+			if entry.Group == "" && entry.File == "" {
+				return nil, errors.New("group option without group or file in global.generator.yml")
+			}
+			option := groupOptionType{group: entry.Group, define: entry.Define, misc: entry.Misc}
+			if entry.File != "" {
+				filterRules, err := newFilterRuleList([]string{entry.File})
+				if err != nil {
+					return nil, err
+				}
+				option.file = &filterRules[0]
+			}
+			policy.options = append(policy.options, option)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// getGroupPolicy returns the group layout of a project, the flat layout is used without entry
+func (cfg *Config) getGroupPolicy(project string) groupPolicyType {
+	policy := groupPolicyType{layout: GroupsFlat}
+	for _, entry := range cfg.groups {
+		if entry.project == project {
+			return entry
+		}
+		if entry.project == "" {
+			policy = entry
+		}
+	}
+	return policy
+}
+
+// matchFolder checks a file against a group rule. Other than a filter rule, a glob
+// matches whole path segments anywhere in the file, e.g. a folder like "Middlewares/ST".
+func (r filterRuleType) matchFolder(file string) bool {
+	file = strings.ReplaceAll(file, "\\", "/")
+	if r.regex != nil {
+		return r.regex.MatchString(file)
+	}
+	segments := strings.Split(file, "/")
+	for i := range segments {
+		for j := i + 1; j <= len(segments); j++ {
+			if ok, _ := path.Match(r.pattern, strings.Join(segments[i:j], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getGroup returns the group of a generated file, e.g. "./Core/Src/main.c"
+func (p groupPolicyType) getGroup(cfg *Config, file string, bridgeParams BridgeParamType) string {
+	for _, rule := range p.rules {
+		if rule.rule.matchFolder(file) {
+			return rule.group
+		}
+	}
+	if p.layout == GroupsTree {
+		return getTreeGroup(cfg, file, bridgeParams)
+	}
+	if strings.Contains(file, "HAL_Driver") {
+		return "STM32 HAL Driver"
+	}
+	return "CubeMX"
+}
+
+// newGroups returns the groups the layout always starts with
+func (p groupPolicyType) newGroups() []cbuild.CgenGroupsType {
+	if p.layout == GroupsFlat {
+		return []cbuild.CgenGroupsType{{Group: "CubeMX"}, {Group: "STM32 HAL Driver"}}
+	}
+	return nil
+}
+
+// addFile adds a file to its group, new groups are appended in the order of their first file
+func (p groupPolicyType) addFile(cfg *Config, groups []cbuild.CgenGroupsType, file string, bridgeParams BridgeParamType) []cbuild.CgenGroupsType {
+	group := p.getGroup(cfg, file, bridgeParams)
+	index := slices.IndexFunc(groups, func(g cbuild.CgenGroupsType) bool { return g.Group == group })
+	if index < 0 {
+		groups = append(groups, cbuild.CgenGroupsType{Group: group})
+		index = len(groups) - 1
+	}
+	groups[index].Files = append(groups[index].Files, cbuild.CgenFilesType{File: file, Category: GetFileCategory(file)})
+	return groups
+}
+
+// applyOptions adds the defines and compiler flags of the options to the groups and files
+func (p groupPolicyType) applyOptions(groups []cbuild.CgenGroupsType) {
+	for _, option := range p.options {
+		for i := range groups {
+			group := &groups[i]
+			if option.group != "" && option.group != group.Group {
+				continue
+			}
+			if option.file == nil {
+				group.Define = append(group.Define, option.define...)
+				group.Misc = append(group.Misc, option.misc...)
+				continue
+			}
+			for j := range group.Files {
+				file := &group.Files[j]
+				if option.file.matchFolder(file.File) {
+					file.Define = append(file.Define, option.define...)
+					file.Misc = append(file.Misc, option.misc...)
+				}
+			}
+		}
+	}
+}
+
+// containsGroupFile checks if a file is already part of a group
+func containsGroupFile(groups []cbuild.CgenGroupsType, file string) bool {
+	for _, group := range groups {
+		if slices.ContainsFunc(group.Files, func(f cbuild.CgenFilesType) bool { return f.File == file }) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTreeGroup derives the group from the CubeMX folder of a file. The file is relative
+// to the output path, e.g. "./STM32CubeMX/Core/Src/main.c", or to the project folder.
+func getTreeGroup(cfg *Config, file string, bridgeParams BridgeParamType) string {
+	file = strings.TrimPrefix(file, "./")
+	file = strings.TrimPrefix(file, filepath.Base(GetProjectFolderPath(""))+"/")
+	segments := strings.Split(file, "/")
+	segments = segments[:len(segments)-1]
+	if len(segments) > 0 && bridgeParams.CubeContextFolder != "" && segments[0] == bridgeParams.CubeContextFolder {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return "Core"
+	}
+
+	if toolchain, err := cfg.getToolchainType(bridgeParams.Compiler); err == nil && toolchain.folder != "" && segments[0] == toolchain.folder {
+		return "Core" // startup file of the toolchain
+	}
+	switch segments[0] {
+	case "Core", "Src", "Inc":
+		return "Core"
+	case "Drivers":
+		if len(segments) < 2 {
+			return "Drivers"
+		}
+		switch {
+		case strings.HasSuffix(segments[1], "HAL_Driver"):
+			return "Drivers/HAL"
+		case segments[1] == "BSP":
+			return "Drivers/BSP"
+		}
+		return "Drivers/" + segments[1]
+	case "Middlewares":
+		if len(segments) > 2 && (segments[1] == "ST" || segments[1] == "Third_Party") {
+			return "Middlewares/" + segments[2]
+		}
+		if len(segments) > 1 {
+			return "Middlewares/" + segments[1]
+		}
+		return "Middlewares"
+	}
+	return segments[0]
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+func Test_getTreeGroup(t *testing.T) {
+	t.Parallel()
+
+	bridgeParams := BridgeParamType{Compiler: "AC6", CubeContextFolder: "CM7"}
+	tests := map[string]string{
+		"./Core/Src/main.c":                                      "Core",
+		"./CM7/Core/Src/main.c":                                  "Core",
+		"./Src/main.c":                                           "Core",
+		"./MDK-ARM/startup_stm32h745xx.s":                        "Core",
+		"./Drivers/STM32H7xx_HAL_Driver/Src/stm32h7xx_hal.c":     "Drivers/HAL",
+		"./Drivers/BSP/STM32H745I-DISCO/stm32h745i_discovery.c":  "Drivers/BSP",
+		"./Drivers/CMSIS/Device/ST/STM32H7xx/Source/system.c":    "Drivers/CMSIS",
+		"./Middlewares/Third_Party/FreeRTOS/Source/tasks.c":      "Middlewares/FreeRTOS",
+		"./Middlewares/ST/STM32_USB_Device_Library/Core/Src/x.c": "Middlewares/STM32_USB_Device_Library",
+		"./USB_DEVICE/App/usb_device.c":                          "USB_DEVICE",
+		"./Common/Src/system_stm32h7xx_dualcore_boot_cm4_cm7.c":  "Common",
+		"./main.c": "Core",
+		// paths relative to the output path above the project folder
+		"./STM32CubeMX/CM7/Core/Src/main.c":                                 "Core",
+		"./STM32CubeMX/MDK-ARM/startup_stm32h745xx.s":                       "Core",
+		"./STM32CubeMX/Drivers/STM32H7xx_HAL_Driver/Src/stm32h7xx_hal.c":    "Drivers/HAL",
+		"./STM32CubeMX/Middlewares/Third_Party/FreeRTOS/Source/tasks.c":     "Middlewares/FreeRTOS",
+		"./STM32CubeMX/Common/Src/system_stm32h7xx_dualcore_boot_cm4_cm7.c": "Common",
+	}
+	for file, want := range tests {
+		if got := getTreeGroup(DefaultConfig(), file, bridgeParams); got != want {
+			t.Errorf("getTreeGroup(%v) = %v, want %v", file, got, want)
+		}
+	}
+}
+
+func Test_SetGroups(t *testing.T) {
+	t.Parallel()
+
+	cubeMX := &CubeMX{}
+	err := cubeMX.SetGroups([]generator.GroupsType{
+		{Layout: "tree"},
+		{Project: "CM4", Rules: []generator.GroupRuleType{
+			{File: "Middlewares/ST/STM32_USB_*", Group: "USB"},
+			{File: "regex:/usbd_desc\\.c$", Group: "USB"},
+		}, Options: []generator.GroupOptionType{
+			{Group: "STM32 HAL Driver", Misc: []cbuild.CgenMiscType{{ForCompiler: "GCC", C: []string{"-w"}}}},
+			{Group: "USB", File: "usbd_*.c", Define: []cbuild.DefineElement{{NameValue: map[string]string{"USBD_DEBUG_LEVEL": "0"}}}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("SetGroups() error = %v", err)
+	}
+	cfg := cubeMX.getConfig()
+
+	if policy := cfg.getGroupPolicy("CM7"); policy.layout != GroupsTree {
+		t.Errorf("getGroupPolicy(CM7) layout = %v, want tree", policy.layout)
+	}
+
+	policy := cfg.getGroupPolicy("CM4")
+	groups := policy.newGroups()
+	for _, file := range []string{
+		"./CM4/Core/Src/main.c",
+		"./Drivers/STM32H7xx_HAL_Driver/Src/stm32h7xx_hal.c",
+		"./CM4/USB_DEVICE/App/usbd_desc.c",
+		"./Middlewares/ST/STM32_USB_Device_Library/Core/Src/usbd_core.c",
+	} {
+		groups = policy.addFile(cfg, groups, file, BridgeParamType{Compiler: "GCC", CubeContextFolder: "CM4"})
+	}
+	want := []cbuild.CgenGroupsType{
+		{Group: "CubeMX", Files: []cbuild.CgenFilesType{{File: "./CM4/Core/Src/main.c", Category: "sourceC"}}},
+		{Group: "STM32 HAL Driver", Files: []cbuild.CgenFilesType{{File: "./Drivers/STM32H7xx_HAL_Driver/Src/stm32h7xx_hal.c", Category: "sourceC"}}},
+		{Group: "USB", Files: []cbuild.CgenFilesType{
+			{File: "./CM4/USB_DEVICE/App/usbd_desc.c", Category: "sourceC"},
+			{File: "./Middlewares/ST/STM32_USB_Device_Library/Core/Src/usbd_core.c", Category: "sourceC"},
+		}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("addFile() = %+v, want %+v", groups, want)
+	}
+
+	policy.applyOptions(groups)
+	if want := []cbuild.CgenMiscType{{ForCompiler: "GCC", C: []string{"-w"}}}; !reflect.DeepEqual(groups[1].Misc, want) {
+		t.Errorf("applyOptions() HAL misc = %+v, want %+v", groups[1].Misc, want)
+	}
+	if groups[2].Define != nil || len(groups[2].Files[0].Define) != 1 || len(groups[2].Files[1].Define) != 1 {
+		t.Errorf("applyOptions() USB defines = %+v", groups[2])
+	}
+	if groups[0].Misc != nil || groups[0].Files[0].Define != nil {
+		t.Errorf("applyOptions() changed group %+v", groups[0])
+	}
+
+	if !containsGroupFile(groups, "./CM4/USB_DEVICE/App/usbd_desc.c") || containsGroupFile(groups, "./CM4/Core/Src/gpio.c") {
+		t.Errorf("containsGroupFile() mismatch for %+v", groups)
+	}
+
+	for _, entry := range []generator.GroupsType{
+		{Layout: "folders"},
+		{Rules: []generator.GroupRuleType{{File: "*.c"}}},
+		{Rules: []generator.GroupRuleType{{File: "regex:(", Group: "X"}}},
+		{Options: []generator.GroupOptionType{{Misc: []cbuild.CgenMiscType{{C: []string{"-w"}}}}}},
+	} {
+		if err := cubeMX.SetGroups([]generator.GroupsType{entry}); err == nil {
+			t.Errorf("SetGroups(%+v) error = nil", entry)
+		}
+	}
+	if policy := cubeMX.getConfig().getGroupPolicy("CM7"); policy.layout != GroupsTree {
+		t.Errorf("SetGroups() error replaced the previous layouts")
+	}
+}
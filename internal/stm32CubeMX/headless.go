@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// headlessTimeout ends a CubeMX run waiting for input that never comes, e.g. a license dialog
+const headlessTimeout = 10 * time.Minute
+
+// GenerateHeadless runs the CubeMX script generating the code without the user interface,
+// waits for CubeMX to end and writes the *.cgen.yml and MX_Device.h files
+func (c *CubeMX) GenerateHeadless(workDir string) error {
+	c.mutex.Lock()
+	if len(c.bridgeParams) == 0 {
+		c.mutex.Unlock()
+		return errors.New("no cbuild-gen entries found for generator " + ID)
+	}
+	params := c.bridgeParams[0]
+	cfg := c.getConfig()
+	c.mutex.Unlock()
+
+	scriptFile, err := WriteGenerateScript(cfg, workDir, params)
+	if err != nil {
+		return err
+	}
+	cmd, err := NewScriptCommand(scriptFile)
+	if err != nil {
+		return err
+	}
+	var fatal []string
+	output := newSessionLog(workDir, func(line string) {
+		fatal = append(fatal, line)
+		c.reportFatal(line)
+	})
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	c.deleteCgenLogs()
+	log.Debugf("Start CubeMX as %v", cmd)
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	timer := time.AfterFunc(headlessTimeout, func() {
+		log.Warnf("CubeMX did not end within %v, stopping it", headlessTimeout)
+		_ = cmd.Process.Kill()
+	})
+	err = cmd.Wait()
+	timer.Stop()
+	_ = output.Close()
+	c.reportExit(cmd.ProcessState, err)
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("CubeMX code generation failed: %w, see %v", err, output.path)
+	case len(fatal) > 0:
+		return fmt.Errorf("CubeMX code generation failed: %v, see %v", fatal[0], output.path)
+	}
+	return c.WriteCgen(workDir)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+)
+
+// writeStubCubeMX installs a CubeMX stub whose java executable runs the shell script body
+func writeStubCubeMX(t *testing.T, body string) {
+	t.Helper()
+	cubeDir := t.TempDir()
+	javaDir := filepath.Join(cubeDir, "jre", "bin")
+	if runtime.GOOS == "darwin" {
+		javaDir = filepath.Join(cubeDir, "jre", "Contents", "Home", "bin")
+	}
+	if err := os.MkdirAll(javaDir, 0750); err != nil {
+		t.Fatalf("os.MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(javaDir, "java"), []byte("#!/bin/sh\n"+body), 0700); err != nil { //nolint:gosec
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	t.Setenv("STM32CubeMX_PATH", cubeDir)
+}
+
+func Test_GenerateHeadless(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the CubeMX stub is a shell script")
+	}
+
+	fixture, err := filepath.Abs("../../testdata/testExamples/STM32F7/STM32CubeMX/STM32F746NGHx/STM32CubeMX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workDir := t.TempDir()
+	argsFile := filepath.Join(t.TempDir(), "args")
+	// CubeMX creates the STM32CubeMX folder with the project and the generated code
+	writeStubCubeMX(t, "echo \"$@\" > '"+argsFile+"'\ncp -R '"+fixture+"' '"+workDir+"/'\necho 'Generating code'\n")
+
+	cgenName := filepath.Join(workDir, "CubeMX.cgen.yml")
+	cubeMX := &CubeMX{bridgeParams: []BridgeParamType{{Device: "ARM::STM32F746NGHx", ProjectName: "CubeMX", Compiler: "AC6", CgenName: cgenName}}}
+
+	scriptFile := filepath.Join(workDir, "generate.script")
+	for _, wantScript := range []string{
+		"load STM32F746NGHx\nproject name STM32CubeMX\nproject toolchain \"MDK-ARM V5\"\nproject path \"" + workDir + "\"\n" +
+			"SetCopyLibrary \"copy only\"\nproject generate\nexit\n",
+		"config load \"" + filepath.Join(workDir, "STM32CubeMX", "STM32CubeMX.ioc") + "\"\nproject generate\nexit\n",
+	} {
+		if err = cubeMX.GenerateHeadless(workDir); err != nil {
+			t.Fatalf("GenerateHeadless() error = %v", err)
+		}
+		script, err := os.ReadFile(scriptFile)
+		if err != nil || string(script) != wantScript {
+			t.Errorf("GenerateHeadless() script = %q, %v, want %q", script, err, wantScript)
+		}
+		args, err := os.ReadFile(argsFile)
+		if err != nil || !strings.HasSuffix(strings.TrimSpace(string(args)), "-q "+scriptFile) {
+			t.Errorf("GenerateHeadless() CubeMX arguments = %q, %v, want -q %v", args, err, scriptFile)
+		}
+		if _, err = os.Stat(cgenName); err != nil {
+			t.Errorf("GenerateHeadless() did not write %v: %v", cgenName, err)
+		}
+		output, err := os.ReadFile(filepath.Join(workDir, sessionLogName))
+		if err != nil || string(output) != "Generating code\n" {
+			t.Errorf("GenerateHeadless() session log = %q, %v", output, err)
+		}
+	}
+
+	writeStubCubeMX(t, "echo 'Exception in thread \"main\" java.lang.IllegalStateException: no license' >&2\nexit 1\n")
+	err = cubeMX.GenerateHeadless(workDir)
+	if err == nil || !strings.Contains(err.Error(), "exit status 1") {
+		t.Fatalf("GenerateHeadless() error = %v, want CubeMX exit status", err)
+	}
+	cgenLog, err := os.ReadFile(cgenlog.GetPath(cgenName))
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	for _, want := range []string{"java.lang.IllegalStateException: no license", "CubeMX exited with code 1"} {
+		if !strings.Contains(string(cgenLog), want) {
+			t.Errorf("GenerateHeadless() cgen.log misses %q:\n%s", want, cgenLog)
+		}
+	}
+}
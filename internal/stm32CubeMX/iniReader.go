@@ -9,7 +9,6 @@ package stm32cubemx
 import (
 	"errors"
 	"fmt"
-	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -97,7 +96,7 @@ func PrintItemIterator(section *ini.Section, key, iterator string) {
 }
 
 func StoreData(data *string, value string) {
-	value = filepath.ToSlash(value)
+	value = strings.ReplaceAll(value, "\\", "/") // .mxproject files written on Windows are read on any host
 
 	if value != "" {
 		*data = value
@@ -106,7 +105,7 @@ func StoreData(data *string, value string) {
 
 func StoreDataArray(data *[]string, values ...string) {
 	for _, value := range values {
-		value = filepath.ToSlash(value)
+		value = strings.ReplaceAll(value, "\\", "/")
 		if value != "" {
 			if !slices.Contains(*data, value) {
 				*data = append(*data, value)
@@ -137,7 +136,23 @@ func StoreItemIterator(data *[]string, section *ini.Section, key, iterator strin
 	}
 }
 
-func IniReader(path string, params []BridgeParamType) (MxprojectAllType, error) {
+// StoreGenFilesLegacy adds the files of older .mxproject files, which list a single folder
+// ("SourcePath=..\Src") and the file names in it ("SourceFiles=main.c;gpio.c;")
+func StoreGenFilesLegacy(files, paths *[]string, section *ini.Section, pathKey, filesKey string) {
+	path := strings.ReplaceAll(section.Key(pathKey).String(), "\\", "/")
+	if path == "" {
+		return
+	}
+	StoreDataArray(paths, path)
+
+	var names []string
+	StoreItemCsv(&names, section, filesKey)
+	for _, name := range names {
+		StoreDataArray(files, path+"/"+strings.TrimSpace(name))
+	}
+}
+
+func IniReader(cfg *Config, path string, params []BridgeParamType) (MxprojectAllType, error) {
 	var mxprojectAll MxprojectAllType
 
 	if !utils.FileExists(path) {
@@ -162,7 +177,7 @@ func IniReader(path string, params []BridgeParamType) (MxprojectAllType, error)
 	for _, param := range params {
 		context := param.CubeContext
 
-		mxproject, _ := GetData(inidata, context, param.Compiler)
+		mxproject, _ := GetData(cfg, inidata, context, param.Compiler)
 		mxproject.Context = context
 		mxprojectAll.Mxproject = append(mxprojectAll.Mxproject, mxproject)
 	}
@@ -209,7 +224,7 @@ func removeVendorAndVersion(ipName string) string {
 	return ipName
 }
 
-func GetData(inidata *ini.File, iniName string, compiler string) (MxprojectType, error) {
+func GetData(cfg *Config, inidata *ini.File, iniName string, compiler string) (MxprojectType, error) {
 	var mxproject MxprojectType
 	var sectionName string
 	var PreviousUsedFilesID string
@@ -252,7 +267,7 @@ func GetData(inidata *ini.File, iniName string, compiler string) (MxprojectType,
 		}
 	}
 
-	PreviousUsedFilesID, err := GetPreviousUsedFilesID(compiler)
+	PreviousUsedFilesID, err := GetPreviousUsedFilesID(cfg, compiler)
 	if err != nil {
 		return mxproject, err
 	}
@@ -297,9 +312,10 @@ func GetData(inidata *ini.File, iniName string, compiler string) (MxprojectType,
 		StoreItemIterator(&mxproject.PreviousGenFiles.HeaderPathList, section, "HeaderFolderListSize", "HeaderPath#")
 		StoreItem(&mxproject.PreviousGenFiles.HeaderFiles, section, "HeaderFiles")
 		StoreItemIterator(&mxproject.PreviousGenFiles.SourceFilesList, section, "SourceFileListSize", "SourceFiles#")
-		StoreItemIterator(&mxproject.PreviousGenFiles.HeaderFilesList, section, "HeaderFileListSize", "HeaderFiles#")
 		StoreItemIterator(&mxproject.PreviousGenFiles.SourcePathList, section, "SourceFolderListSize", "SourcePath#")
 		StoreItem(&mxproject.PreviousGenFiles.SourceFiles, section, "SourceFiles")
+		StoreGenFilesLegacy(&mxproject.PreviousGenFiles.HeaderFilesList, &mxproject.PreviousGenFiles.HeaderPathList, section, "HeaderPath", "HeaderFiles")
+		StoreGenFilesLegacy(&mxproject.PreviousGenFiles.SourceFilesList, &mxproject.PreviousGenFiles.SourcePathList, section, "SourcePath", "SourceFiles")
 
 		PrintItem(section, "AdvancedFolderStructure")
 		PrintItemIterator(section, "HeaderFileListSize", "HeaderFiles#")
@@ -314,17 +330,10 @@ func GetData(inidata *ini.File, iniName string, compiler string) (MxprojectType,
 	return mxproject, nil
 }
 
-func GetPreviousUsedFilesID(compiler string) (string, error) {
-	var sectionMapping = map[string]string{
-		"AC6":   "PreviousUsedKeilFiles",
-		"GCC":   "PreviousUsedCubeIDEFiles",
-		"IAR":   "PreviousUsedIarFiles",
-		"CLANG": "PreviousUsedCubeIDEFiles",
-	}
-
-	PreviousUsedFilesID, ok := sectionMapping[compiler]
-	if !ok {
-		return "", errors.New("unknown compiler")
+func GetPreviousUsedFilesID(cfg *Config, compiler string) (string, error) {
+	toolchain, err := cfg.getToolchainType(compiler)
+	if err != nil {
+		return "", err
 	}
-	return PreviousUsedFilesID, nil
+	return toolchain.filesID, nil
 }
@@ -111,6 +111,12 @@ source=lib_src.c ;lib_more.c
 SourceFiles=main.c ;utils.c
 HeaderPath=./inc ;./inc2
 CDefines=KEIL_DEF ;OTHER
+
+[PreviousGenFiles]
+HeaderPath=../Inc
+HeaderFiles=gpio.h;main.h;
+SourcePath=../Src
+SourceFiles=gpio.c;main.c;
 `
 
 	tests := []struct {
@@ -201,6 +207,16 @@ CDefines=KEIL_DEF ;OTHER
 				if !containsAll(mx.PreviousUsedFiles.CDefines, []string{"KEIL_DEF", "OTHER"}) {
 					t.Errorf("CDefines expected KEIL_DEF/OTHER: %v", mx.PreviousUsedFiles.CDefines)
 				}
+				// PreviousGenFiles of older CubeMX versions
+				if !reflect.DeepEqual(mx.PreviousGenFiles.HeaderFilesList, []string{"../Inc/gpio.h", "../Inc/main.h"}) {
+					t.Errorf("HeaderFilesList mismatch: %v", mx.PreviousGenFiles.HeaderFilesList)
+				}
+				if !reflect.DeepEqual(mx.PreviousGenFiles.SourceFilesList, []string{"../Src/gpio.c", "../Src/main.c"}) {
+					t.Errorf("SourceFilesList mismatch: %v", mx.PreviousGenFiles.SourceFilesList)
+				}
+				if !reflect.DeepEqual(mx.PreviousGenFiles.SourcePathList, []string{"../Src"}) {
+					t.Errorf("SourcePathList mismatch: %v", mx.PreviousGenFiles.SourcePathList)
+				}
 			},
 		},
 		{
@@ -229,7 +245,7 @@ CDefines=KEIL_DEF ;OTHER
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			mx, err := GetData(tt.inidata, tt.ctx, tt.compiler)
+			mx, err := GetData(DefaultConfig(), tt.inidata, tt.ctx, tt.compiler)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("GetData() error=%v wantErr=%v", err, tt.wantErr)
 			}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
+)
+
+// MiddlewareSource selects where the middlewares enabled in the .ioc file come from
+type MiddlewareSource string
+
+const (
+	// MiddlewareFromCubeMX compiles the middleware sources copied by CubeMX
+	MiddlewareFromCubeMX MiddlewareSource = "cubemx"
+	// MiddlewareFromPacks requires the CMSIS packs, the copied sources are not added
+	MiddlewareFromPacks MiddlewareSource = "packs"
+)
+
+// ParseMiddlewareSource checks the middleware option
+func ParseMiddlewareSource(source string) (MiddlewareSource, error) {
+	switch MiddlewareSource(source) {
+	case MiddlewareFromCubeMX, MiddlewareFromPacks:
+		return MiddlewareSource(source), nil
+	}
+	return "", errors.New("unknown middleware source '" + source + "', use 'cubemx' or 'packs'")
+}
+
+// middlewareType maps a CubeMX middleware to the CMSIS packs and components replacing it
+type middlewareType struct {
+	ip         string   // middleware IP in the .ioc file
+	name       string   // display name
+	packs      []string // packs providing the components
+	components []string // components to select in the cproject instead of the copied sources
+	folders    []string // folders of the sources copied by CubeMX
+}
+
+var middlewares = []middlewareType{
+	{
+		ip: "FREERTOS", name: "FreeRTOS",
+		packs:      []string{"ARM::CMSIS-FreeRTOS", "ARM::CMSIS"},
+		components: []string{"CMSIS:RTOS2:FreeRTOS&Cortex-M", "RTOS&FreeRTOS:Core&Cortex-M", "RTOS&FreeRTOS:Config&CMSIS RTOS2", "RTOS&FreeRTOS:Heap&Heap_4"},
+		folders:    []string{"Middlewares/Third_Party/FreeRTOS"},
+	},
+	{
+		ip: "USB_DEVICE", name: "USB Device",
+		packs:      []string{"Keil::MDK-Middleware"},
+		components: []string{"USB:CORE", "USB:Device"},
+		folders:    []string{"Middlewares/ST/STM32_USB_Device_Library"},
+	},
+	{
+		ip: "USB_HOST", name: "USB Host",
+		packs:      []string{"Keil::MDK-Middleware"},
+		components: []string{"USB:CORE", "USB:Host"},
+		folders:    []string{"Middlewares/ST/STM32_USB_Host_Library"},
+	},
+	{
+		ip: "FATFS", name: "FatFs",
+		packs:      []string{"Keil::MDK-Middleware"},
+		components: []string{"File System&MDK:Core"},
+		folders:    []string{"Middlewares/Third_Party/FatFs"},
+	},
+	{
+		ip: "LWIP", name: "lwIP",
+		packs:      []string{"lwIP::lwIP"},
+		components: []string{"Network:CORE&IPv4", "Network:API", "Network:Interface:ETH"},
+		folders:    []string{"Middlewares/Third_Party/LwIP"},
+	},
+	{
+		ip: "MBEDTLS", name: "mbed TLS",
+		packs:      []string{"ARM::mbedTLS"},
+		components: []string{"Security:mbed TLS"},
+		folders:    []string{"Middlewares/Third_Party/mbedTLS"},
+	},
+}
+
+// findMiddleware returns the table entry of a middleware IP, nil if unknown
+func findMiddleware(ip string) *middlewareType {
+	for i := range middlewares {
+		if middlewares[i].ip == ip {
+			return &middlewares[i]
+		}
+	}
+	return nil
+}
+
+// getMiddlewares returns the known middleware IPs enabled in the context
+func getMiddlewares(iocData *ioc.Ioc, context string) []string {
+	var names []string
+	contextData, hasContext := iocData.Context(context)
+	for _, ip := range iocData.Mcu.IPs {
+		if findMiddleware(ip) == nil {
+			continue
+		}
+		if context != "" && hasContext && contextData.IPs != nil && !contextData.HasIP(ip) {
+			continue
+		}
+		names = append(names, ip)
+	}
+	return names
+}
+
+// isPackMiddlewareFile checks if a file or include path belongs to the copied sources
+// of a middleware that is taken from CMSIS packs
+func (cfg *Config) isPackMiddlewareFile(file string, names []string) bool {
+	if cfg.middleware != MiddlewareFromPacks {
+		return false
+	}
+	file = "/" + strings.ReplaceAll(file, "\\", "/") + "/"
+	for _, name := range names {
+		middleware := findMiddleware(name)
+		if middleware == nil {
+			continue
+		}
+		for _, folder := range middleware.folders {
+			if strings.Contains(file, "/"+folder+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
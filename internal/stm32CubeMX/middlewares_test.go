@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseMiddlewareSource(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []string{"cubemx", "packs"} {
+		if got, err := ParseMiddlewareSource(source); err != nil || string(got) != source {
+			t.Errorf("ParseMiddlewareSource(%v) = %v, %v", source, got, err)
+		}
+	}
+	if _, err := ParseMiddlewareSource("vendor"); err == nil {
+		t.Errorf("ParseMiddlewareSource() error = nil for unknown source")
+	}
+}
+
+func Test_getMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "Mcu.IP0=RCC\nMcu.IP1=FREERTOS\nMcu.IP2=USB_DEVICE\nMcu.IP3=LWIP\n"+
+		"Mcu.Context0=CortexM7\nMcu.Context1=CortexM4\n"+
+		"CortexM7.IPs=RCC,FREERTOS,LWIP\nCortexM4.IPs=RCC\\:I,USB_DEVICE\n")
+
+	tests := map[string][]string{
+		"":         {"FREERTOS", "USB_DEVICE", "LWIP"},
+		"CortexM7": {"FREERTOS", "LWIP"},
+		"CortexM4": {"USB_DEVICE"},
+	}
+	for context, want := range tests {
+		if got := getMiddlewares(iocData, context); !reflect.DeepEqual(got, want) {
+			t.Errorf("getMiddlewares(%v) = %v, want %v", context, got, want)
+		}
+	}
+}
+
+func Test_MiddlewareFromPacks(t *testing.T) {
+	t.Parallel()
+
+	cfg := *DefaultConfig()
+	cfg.middleware = MiddlewareFromPacks
+
+	names := []string{"FREERTOS", "USB_DEVICE"}
+	files := map[string]bool{
+		"../Middlewares/Third_Party/FreeRTOS/Source/tasks.c":                    true,
+		"..\\Middlewares\\ST\\STM32_USB_Device_Library\\Core\\Src\\usbd_core.c": true,
+		"./Middlewares/Third_Party/FreeRTOS/Source/include":                     true,
+		"../Middlewares/Third_Party/FatFs/src/ff.c":                             false,
+		"../Core/Src/freertos.c":                                                false,
+	}
+	for file, want := range files {
+		if got := cfg.isPackMiddlewareFile(file, names); got != want {
+			t.Errorf("isPackMiddlewareFile(%v) = %v, want %v", file, got, want)
+		}
+	}
+
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "STM32CubeMX")
+	for _, file := range []string{
+		"MDK-ARM/startup_stm32f429xx.s",
+		"Core/Src/main.c",
+		"Core/Src/freertos.c",
+		"Core/Src/system_stm32f4xx.c",
+		"Middlewares/Third_Party/FreeRTOS/Source/tasks.c",
+		"Middlewares/Third_Party/FreeRTOS/Source/include/task.h",
+	} {
+		file = filepath.Join(base, file)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(""), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mx := MxprojectType{}
+	mx.PreviousUsedFiles.SourceFiles = []string{"../Core/Src/main.c", "../Core/Src/freertos.c", "../Middlewares/Third_Party/FreeRTOS/Source/tasks.c"}
+	mx.PreviousUsedFiles.HeaderPath = []string{"../Middlewares/Third_Party/FreeRTOS/Source/include"}
+	bp := BridgeParamType{Compiler: "AC6", CgenName: filepath.Join(tmpDir, "Cgen.yml"), MainLocation: "Core/Src", Middlewares: []string{"FREERTOS"}}
+	if err := WriteCgenYmlSub(&cfg, base, mx, bp); err != nil {
+		t.Fatalf("WriteCgenYmlSub error: %v", err)
+	}
+
+	data, err := os.ReadFile(bp.CgenName)
+	if err != nil {
+		t.Fatalf("read cgen: %v", err)
+	}
+	var cgen cbuild.CgenType
+	if err := yaml.Unmarshal(data, &cgen); err != nil {
+		t.Fatalf("parse cgen: %v", err)
+	}
+	if want := []cbuild.CgenPacksType{{Pack: "ARM::CMSIS-FreeRTOS"}, {Pack: "ARM::CMSIS"}}; !reflect.DeepEqual(cgen.GeneratorImport.Packs, want) {
+		t.Errorf("packs = %v, want %v", cgen.GeneratorImport.Packs, want)
+	}
+	if want := []string{"./MX_Device"}; !reflect.DeepEqual(cgen.GeneratorImport.AddPath, want) {
+		t.Errorf("add-path = %v, want %v", cgen.GeneratorImport.AddPath, want)
+	}
+	var groupFiles []string
+	for _, file := range cgen.GeneratorImport.Groups[0].Files {
+		groupFiles = append(groupFiles, file.File)
+	}
+	if want := []string{"./Core/Src/main.c", "./Core/Src/freertos.c", "./MDK-ARM/startup_stm32f429xx.s", "./Core/Src/system_stm32f4xx.c"}; !reflect.DeepEqual(groupFiles, want) {
+		t.Errorf("CubeMX files = %v, want %v", groupFiles, want)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -33,26 +35,35 @@ type PinDefinition struct {
 	alternate string
 }
 
-func ReadContexts(iocFile string, params []BridgeParamType) error {
-	contextMap, err := createContextMap(iocFile)
+func ReadContexts(cfg *Config, iocFile string, params []BridgeParamType) error {
+	return readContexts(cfg, iocFile, params, filepath.Join(filepath.Dir(filepath.Dir(iocFile)), "MX_Device"))
+}
+
+// readContexts writes the MX_Device.h files of all contexts below cfgRoot
+func readContexts(cfg *Config, iocFile string, params []BridgeParamType, cfgRoot string) error {
+	iocData, err := ioc.Read(iocFile)
 	if err != nil {
 		return err
 	}
 
-	contexts, err := getContexts(contextMap)
-	if err != nil {
-		return err
+	contexts := []string{""}
+	if len(iocData.Contexts) > 0 {
+		contexts = nil
+		for _, context := range iocData.Contexts {
+			contexts = append(contexts, context.Name)
+		}
 	}
 
 	workDir := filepath.Dir(iocFile)
 
-	mainFolder := contextMap["ProjectManager"]["MainLocation"]
+	mainFolder := iocData.ProjectManager.MainLocation
 	if mainFolder == "" {
 		return errors.New("main location missing")
 	}
 
 	for i := range params {
 		params[i].MainLocation = mainFolder
+		params[i].Middlewares = getMiddlewares(iocData, params[i].CubeContext)
 	}
 
 	for _, context := range contexts {
@@ -72,13 +83,11 @@ func ReadContexts(iocFile string, params []BridgeParamType) error {
 					return err
 				}
 
-				var cfgPath string
-				cfgPath = filepath.Dir(workDir)
-				cfgPath = filepath.Join(cfgPath, "MX_Device")
+				cfgPath := cfgRoot
 				if parm.CubeContextFolder != "" {
 					cfgPath = filepath.Join(cfgPath, parm.CubeContextFolder)
 				}
-				err := writeMXdeviceH(contextMap, srcFolderPath, mspName, cfgPath, context, parm.CgenName)
+				err := writeMXdeviceH(cfg.mxDevice, iocData, srcFolderPath, mspName, cfgPath, context, parm.CgenName)
 				if err != nil {
 					return err
 				}
@@ -90,46 +99,19 @@ func ReadContexts(iocFile string, params []BridgeParamType) error {
 	return nil
 }
 
-func createContextMap(iocFile string) (map[string]map[string]string, error) {
-	contextMap := make(map[string]map[string]string)
-	var xMap map[string]string
-
-	fIoc, err := os.Open(iocFile)
-	if err != nil {
-		return nil, err
-	}
-	defer fIoc.Close()
-	iocScan := bufio.NewScanner(fIoc)
-	iocScan.Split(bufio.ScanLines)
-	for iocScan.Scan() {
-		split := strings.Split(iocScan.Text(), "=")
-		if len(split) > 1 {
-			leftParts := strings.Split(split[0], ".")
-			if len(leftParts) > 1 {
-				if contextMap[leftParts[0]] == nil {
-					xMap = make(map[string]string)
-					contextMap[leftParts[0]] = xMap
-				}
-				xMap[leftParts[1]] = split[1]
-			}
-		}
-	}
-	return contextMap, nil
-}
-
-func writeMXdeviceH(contextMap map[string]map[string]string, srcFolder string, mspName string, cfgPath string, context string, cgenPath string) error {
+func writeMXdeviceH(mode MXDeviceSource, iocData *ioc.Ioc, srcFolder string, mspName string, cfgPath string, context string, cgenPath string) error {
 
 	srcFolderAbs, err := filepath.Abs(srcFolder)
 	if err != nil {
 		return err
 	}
 
-	generatedAsPair := contextMap["ProjectManager"]["CoupleFile"]
+	generatedAsPair := iocData.ProjectManager.CoupleFile
 
 	var fMain *os.File
 	var fMsp *os.File
 
-	if generatedAsPair != "true" {
+	if !generatedAsPair {
 		main := filepath.Join(srcFolderAbs, "main.c")
 		main = filepath.ToSlash(main)
 
@@ -176,126 +158,109 @@ func writeMXdeviceH(contextMap map[string]map[string]string, srcFolder string, m
 		return err
 	}
 
-	peripherals, err := getPeripherals(contextMap, context)
+	peripherals, err := getPeripherals(iocData, context)
 	if err != nil {
 		return err
 	}
 	sort.Strings(peripherals)
 	for _, peripheral := range peripherals {
-		vmode := getVirtualMode(contextMap, peripheral)
+		entry := findMXPeripheral(peripheral)
+		vmode := getVirtualMode(iocData, peripheral)
 		var i2cInfo map[string]string
 		var usbHandle string
 		var mciMode string
 		var pins map[string]PinDefinition
 
-		freq := getI2CFreq(contextMap, peripheral)
+		freq := getI2CFreq(iocData, peripheral)
 		if freq == "" {
-			freq = getMMCFreq(contextMap, peripheral)
+			freq = getMMCFreq(iocData, peripheral)
 		}
-
-		if generatedAsPair == "true" {
-
-			periName := map[string]string{
-				"USART":  "usart.c",
-				"UART":   "usart.c",
-				"LPUART": "usart.c",
-				"SPI":    "spi.c",
-				"I2C":    "i2c.c",
-				"ETH":    "eth.c",
-				"SDMMC":  "sdmmc.c",
-				"CAN":    "can.c",
-				"USB":    "usb.c",
-				"SDIO":   "sdio.c"}
-
-			for key, value := range periName {
-				/* search for peripherals to handle pins*/
-				if strings.Contains(peripheral, key) {
-					fileName := value
-					if strings.Contains(peripheral, "OTG") {
-						fileName = "usb_otg.c"
-					} else if strings.Contains(peripheral, "FD") {
-						fileName = "fdcan.c"
-					}
-					periPath := filepath.Join(srcFolderAbs, fileName)
-					periPath = filepath.ToSlash(periPath)
-					err = func() error {
-						fPeri, errPeri := os.Open(periPath)
-						if errPeri != nil {
-							warnErr := fmt.Errorf("warning: failed to open peripheral source '%s' for '%s': %w", periPath, peripheral, errPeri)
-							logCgenError(cgenPath, warnErr)
-							log.Warnf("%v", warnErr)
-							return nil
-						}
-						defer fPeri.Close()
-
-						pins, err = getPins(contextMap, fPeri, peripheral)
-						if err != nil {
-							return err
-						}
-
-						/* peripherals custom infos */
-						if strings.Contains(peripheral, "I2C") {
-							i2cInfo, err = getI2cInfo(fPeri, peripheral)
-							if err != nil {
-								return err
-							}
-						} else if strings.Contains(peripheral, "USB") {
-							usbHandle, err = getUSBHandle(fPeri, peripheral)
-							if err != nil {
-								return err
-							}
-						} else if strings.Contains(peripheral, "SDMMC") {
-							mciMode, err = getMCIMode(fPeri, peripheral)
-							if err != nil {
-								return err
-							}
-						} else if strings.Contains(peripheral, "SDIO") {
-							mciMode, err = getMCIMode(fPeri, peripheral)
-							if err != nil {
-								return err
-							}
-						} else if strings.Contains(peripheral, "SPI") {
-							if freq == "" {
-								freq = getSPIFreq(fPeri, contextMap, peripheral)
-							}
-						}
-
+		if freq == "" {
+			freq = getPeriphClockFreq(iocData, peripheral, entry.clocks)
+		}
+
+		switch {
+		case entry.pins != nil:
+			pins = entry.pins(iocData, context)
+		case generatedAsPair:
+			/* search for peripherals to handle pins*/
+			periPath := filepath.Join(srcFolderAbs, entry.source)
+			periPath = filepath.ToSlash(periPath)
+			err = func() error {
+				fPeri, errPeri := os.Open(periPath)
+				if errPeri != nil {
+					warnErr := fmt.Errorf("warning: failed to open peripheral source '%s' for '%s': %w", periPath, peripheral, errPeri)
+					cgenlog.Error(cgenPath, warnErr)
+					log.Warnf("%v", warnErr)
+					if mode != MXDeviceFromIoc {
 						return nil
-					}()
-					if err != nil {
-						return err
 					}
-					break
-				}
-			}
-		} else {
-			if fMain != nil {
-				i2cInfo, err = getI2cInfo(fMain, peripheral)
-				if err != nil {
-					return err
-				}
-				usbHandle, err = getUSBHandle(fMain, peripheral)
-				if err != nil {
-					return err
+					fPeri = nil // values from .ioc only
+				} else {
+					defer fPeri.Close()
 				}
-				mciMode, err = getMCIMode(fMain, peripheral)
+
+				pins, err = readPins(mode, iocData, fPeri, peripheral)
 				if err != nil {
 					return err
 				}
 
-				if freq == "" {
-					freq = getSPIFreq(fMain, contextMap, peripheral)
+				/* peripherals custom infos */
+				if strings.Contains(peripheral, "I2C") {
+					i2cInfo, err = readI2cInfo(mode, iocData, fPeri, peripheral)
+					if err != nil {
+						return err
+					}
+				} else if strings.Contains(peripheral, "USB") {
+					usbHandle, err = readUSBHandle(mode, iocData, fPeri, peripheral)
+					if err != nil {
+						return err
+					}
+				} else if strings.Contains(peripheral, "SDMMC") {
+					mciMode, err = readMCIMode(mode, iocData, fPeri, peripheral)
+					if err != nil {
+						return err
+					}
+				} else if strings.Contains(peripheral, "SDIO") {
+					mciMode, err = readMCIMode(mode, iocData, fPeri, peripheral)
+					if err != nil {
+						return err
+					}
+				} else if strings.Contains(peripheral, "SPI") {
+					if freq == "" {
+						freq = getSPIFreq(fPeri, iocData, peripheral)
+					}
 				}
+
+				return nil
+			}()
+			if err != nil {
+				return err
 			}
-			if fMsp != nil {
-				pins, err = getPins(contextMap, fMsp, peripheral)
-				if err != nil {
-					return err
-				}
+		default:
+			i2cInfo, err = readI2cInfo(mode, iocData, fMain, peripheral)
+			if err != nil {
+				return err
+			}
+			usbHandle, err = readUSBHandle(mode, iocData, fMain, peripheral)
+			if err != nil {
+				return err
+			}
+			mciMode, err = readMCIMode(mode, iocData, fMain, peripheral)
+			if err != nil {
+				return err
+			}
+			if freq == "" {
+				freq = getSPIFreq(fMain, iocData, peripheral)
+			}
+			pins, err = readPins(mode, iocData, fMsp, peripheral)
+			if err != nil {
+				return err
 			}
 		}
 
-		err = mxDeviceWritePeripheralCfg(out, peripheral, vmode, freq, i2cInfo, usbHandle, mciMode, pins)
+		sections := getMXSections(iocData, entry, peripheral, context)
+		err = mxDeviceWritePeripheralCfg(out, peripheral, vmode, freq, i2cInfo, usbHandle, mciMode, sections, pins)
 		if err != nil {
 			return err
 		}
@@ -343,86 +308,51 @@ func writeMXdeviceH(contextMap map[string]map[string]string, srcFolder string, m
 		return pinConfigMap, nil
 	}
 */
-func getContexts(contextMap map[string]map[string]string) (map[int]string, error) {
-	contexts := make(map[int]string)
-	head := contextMap["Mcu"]
-	if len(head) > 0 {
-		for key, content := range head {
-			if strings.HasPrefix(key, "Context") {
-				l := len(key)
-				if l > 0 && key[l-1] >= '0' && key[l-1] <= '9' {
-					i, err := strconv.Atoi(string(key[l-1]))
-					if err != nil {
-						return nil, err
-					}
-					contexts[i] = content
-				}
-			}
-		}
-	}
-	if len(contexts) == 0 {
-		contexts[0] = ""
-	}
-	return contexts, nil
-}
-
-func getPeripherals(contextMap map[string]map[string]string, context string) ([]string, error) {
-	PERIPHERALS := [...]string{"USART", "UART", "LPUART", "SPI", "I2C", "ETH", "SDMMC", "CAN", "USB", "SDIO", "FDCAN"}
+func getPeripherals(iocData *ioc.Ioc, context string) ([]string, error) {
 	var peripherals []string
-	var contextIpsLine string
+	var contextData ioc.ContextType
 	if len(context) > 0 {
-		contextIps, ok := contextMap[context]
+		var ok bool
+		contextData, ok = iocData.Context(context)
 		if !ok {
 			return nil, errors.New("context not found in ioc")
 		}
-		contextIpsLine, ok = contextIps["IPs"]
-		if !ok {
+		if contextData.IPs == nil {
 			return nil, errors.New("IPs not found in context")
 		}
 	}
-	mcu := contextMap["Mcu"]
-	if mcu != nil {
-		for ip, peri := range mcu {
-			if strings.HasPrefix(ip, "IP") {
-				if len(context) == 0 || strings.Contains(contextIpsLine, peri) {
-					for _, peripheral := range PERIPHERALS {
-						if strings.HasPrefix(peri, peripheral) {
-							peripherals = append(peripherals, peri)
-							break
-						}
-					}
-				}
+	if iocData.Mcu.Params == nil {
+		return nil, errors.New("peripheral not found in Mcu")
+	}
+	for _, peri := range iocData.Mcu.IPs {
+		if len(context) == 0 || contextData.HasIP(peri) {
+			if entry := findMXPeripheral(peri); entry != nil && entry.present == nil {
+				peripherals = append(peripherals, peri)
 			}
 		}
-	} else {
-		return nil, errors.New("peripheral not found in Mcu")
+	}
+	for _, entry := range mxPeripherals {
+		if entry.present != nil && entry.present(iocData, context) {
+			peripherals = append(peripherals, entry.family)
+		}
 	}
 	return peripherals, nil
 }
 
-func getVirtualMode(contextMap map[string]map[string]string, peripheral string) string {
-	peri := contextMap[peripheral]
-	if len(peri) > 0 {
-		for vm, vmode := range peri {
-			if strings.HasPrefix(vm, "VirtualMode") {
-				return vmode
-			}
-		}
-	}
-	return ""
+func getVirtualMode(iocData *ioc.Ioc, peripheral string) string {
+	return iocData.IPs[peripheral].VirtualMode
 }
 
-func getPins(contextMap map[string]map[string]string, fMsp *os.File, peripheral string) (map[string]PinDefinition, error) {
+func getPins(iocData *ioc.Ioc, fMsp *os.File, peripheral string) (map[string]PinDefinition, error) {
 	pinsName := make(map[string]string)
 	pinsLabel := make(map[string]string)
 	pinsInfo := make(map[string]PinDefinition)
-	for key, signal := range contextMap {
+	for key, pinData := range iocData.Pins {
 		if !strings.HasPrefix(key, "VP") {
-			peri := signal["Signal"]
-			if strings.HasPrefix(peri, peripheral) {
+			if peri, ok := peripheralSignal(pinData.Signal, peripheral); ok {
 				pinsName[key] = peri
-				label, ok := signal["GPIO_Label"]
-				if ok {
+				label := pinData.Label
+				if label != "" {
 					label = strings.Split(label, "[")[0]
 					label = strings.TrimRight(label, " ")
 					label = replaceSpecialChars(label, "_")
@@ -432,8 +362,7 @@ func getPins(contextMap map[string]map[string]string, fMsp *os.File, peripheral
 		}
 	}
 	for pin, name := range pinsName {
-		p := strings.Split(pin, "\\")[0]
-		p = strings.Split(p, "(")[0]
+		p := strings.Split(pin, "(")[0]
 		p = strings.Split(p, " ")[0]
 		p = strings.Split(p, "_")[0]
 		p = strings.Split(p, "-")[0]
@@ -590,28 +519,25 @@ func getMCIMode(fMain *os.File, peripheral string) (string, error) {
 }
 
 // Get MMC Freq
-func getMMCFreq(contextMap map[string]map[string]string, peripheral string) string {
+func getMMCFreq(iocData *ioc.Ioc, peripheral string) string {
 	var freq string
 
 	if !strings.HasPrefix(peripheral, "SDMMC") && !strings.HasPrefix(peripheral, "SDIO") {
 		return ""
 	}
 
-	freq = getUserConstant(contextMap, peripheral+"_PERIPH_CLOCK_FREQ")
+	freq = getUserConstant(iocData, peripheral+"_PERIPH_CLOCK_FREQ")
 	if freq != "" {
 		// Frequency defined by user in CubeMX
 		return freq
 	}
 
-	peri := contextMap["RCC"]
-
-	if len(peri) > 0 {
-		periphStrings := [2]string{peripheral, strings.TrimRight(peripheral, getDigitAtEnd(peripheral))}
-		for _, ps := range periphStrings {
-			for p, freq := range peri {
-				if strings.HasPrefix(p, ps) && strings.Contains(p, "Freq_Value") {
-					return freq
-				}
+	periphStrings := [2]string{peripheral, strings.TrimRight(peripheral, getDigitAtEnd(peripheral))}
+	for _, ps := range periphStrings {
+		for _, name := range iocData.RCC.FrequencyNames() {
+			if strings.HasPrefix(name, ps) {
+				freq, _ = iocData.RCC.Frequency(name)
+				return freq
 			}
 		}
 	}
@@ -619,30 +545,25 @@ func getMMCFreq(contextMap map[string]map[string]string, peripheral string) stri
 }
 
 // Get I2C Freq
-func getI2CFreq(contextMap map[string]map[string]string, peripheral string) string {
+func getI2CFreq(iocData *ioc.Ioc, peripheral string) string {
 	var freq string
 
 	if !strings.HasPrefix(peripheral, "I2C") {
 		return ""
 	}
 
-	freq = getUserConstant(contextMap, peripheral+"_PERIPH_CLOCK_FREQ")
+	freq = getUserConstant(iocData, peripheral+"_PERIPH_CLOCK_FREQ")
 	if freq != "" {
 		// Frequency defined by user in CubeMX
 		return freq
 	}
 
-	peri := contextMap["RCC"]
-	if len(peri) > 0 {
-		for p, freq := range peri {
-			if strings.HasPrefix(p, "I2C") && strings.Contains(p, "Freq_Value") {
-				split := strings.Split(p, "I2C")[1]
-				pIdx := strings.Split(split, "Freq_Value")[0]
-
-				digit := getDigitAtEnd(peripheral)
-				if strings.Contains(pIdx, digit) {
-					return freq
-				}
+	for _, name := range iocData.RCC.FrequencyNames() {
+		if pIdx, found := strings.CutPrefix(name, "I2C"); found {
+			digit := getDigitAtEnd(peripheral)
+			if strings.Contains(pIdx, digit) {
+				freq, _ = iocData.RCC.Frequency(name)
+				return freq
 			}
 		}
 	}
@@ -650,49 +571,34 @@ func getI2CFreq(contextMap map[string]map[string]string, peripheral string) stri
 }
 
 // Get SPI Freq
-func getSPIFreq(fMain *os.File, contextMap map[string]map[string]string, peripheral string) string {
+func getSPIFreq(fMain *os.File, iocData *ioc.Ioc, peripheral string) string {
 	var freq string
 
 	if !strings.HasPrefix(peripheral, "SPI") {
 		return ""
 	}
 
-	freq = getUserConstant(contextMap, peripheral+"_PERIPH_CLOCK_FREQ")
+	freq = getUserConstant(iocData, peripheral+"_PERIPH_CLOCK_FREQ")
 	if freq != "" {
 		// Frequency defined by user in CubeMX
 		return freq
 	}
 
 	// Search for "RCC.I2C1Freq_Value" in ioc
-	peri := contextMap["RCC"]
-	if len(peri) > 0 {
-		for key, value := range peri {
-			if strings.HasPrefix(key, "SPI") && strings.Contains(key, "Freq_Value") {
-				split := strings.Split(key, "SPI")[1]
-				pIdx := strings.Split(split, "Freq_Value")[0]
-
-				digit := getDigitAtEnd(peripheral)
-				if strings.Contains(pIdx, digit) {
-					return value
-				}
+	for _, name := range iocData.RCC.FrequencyNames() {
+		if pIdx, found := strings.CutPrefix(name, "SPI"); found {
+			digit := getDigitAtEnd(peripheral)
+			if strings.Contains(pIdx, digit) {
+				freq, _ = iocData.RCC.Frequency(name)
+				return freq
 			}
 		}
 	}
 
-	// Search for "SPIx.CalculateBaudRate=" and "SPIx.CalculateBaudRate=" in ioc
-	calcBR := ""
-	prescaler := ""
-	peri = contextMap[peripheral]
-	if len(peri) > 0 {
-		for key, value := range peri {
-			if key == "CalculateBaudRate" {
-				calcBR = value
-			}
-			if key == "BaudRatePrescaler" {
-				prescaler = value
-			}
-		}
-	}
+	// Search for "SPIx.CalculateBaudRate=" and "SPIx.BaudRatePrescaler=" in ioc
+	peri := iocData.Section(peripheral)
+	calcBR := peri["CalculateBaudRate"]
+	prescaler := peri["BaudRatePrescaler"]
 
 	if calcBR == "" {
 		// CalculateBaudRate should beavailable in .ioc
@@ -758,24 +664,10 @@ func getSPIFreq(fMain *os.File, contextMap map[string]map[string]string, periphe
 	return freq
 }
 
-func getUserConstant(contextMap map[string]map[string]string, constant string) string {
+func getUserConstant(iocData *ioc.Ioc, constant string) string {
 	// Search for "Mcu.UserConstants" in ioc
-	peri := contextMap["Mcu"]
-	if len(peri) > 0 {
-		for key, value := range peri {
-			if key == "UserConstants" {
-				constants := strings.Split(value, ";")
-				for _, c := range constants {
-					split := strings.Split(c, ",")
-					if split[0] == constant {
-						return strings.Trim(split[1], " ")
-					}
-				}
-			}
-		}
-	}
-
-	return ""
+	value, _ := iocData.UserConstant(constant)
+	return value
 }
 
 func getPinConfiguration(fMsp *os.File, peripheral string, pin string, label string) (PinDefinition, error) {
@@ -807,7 +699,7 @@ func getPinConfiguration(fMsp *os.File, peripheral string, pin string, label str
 		if line == "}" { // end of function
 			section = false // reset instance
 		}
-		if strings.Contains(line, s) && strings.Contains(line, peripheral) {
+		if isInstanceLine(line, s, peripheral) {
 			section = true
 		}
 		if section {
@@ -861,6 +753,21 @@ func getPinConfiguration(fMsp *os.File, peripheral string, pin string, label str
 	return PinDefinition{}, nil
 }
 
+// isInstanceLine checks for "->Instance==" of the peripheral (TIM1 but not TIM12)
+func isInstanceLine(line string, s string, peripheral string) bool {
+	idx := strings.Index(line, s)
+	if idx == -1 {
+		return false
+	}
+	line = line[idx:]
+	idx = strings.Index(line, peripheral)
+	if idx == -1 {
+		return false
+	}
+	rest := line[idx+len(peripheral):]
+	return rest == "" || rest[0] < '0' || rest[0] > '9'
+}
+
 func mxDeviceWriteHeader(out *bufio.Writer, fName string) error {
 	now := time.Now()
 	dtString := now.Format("02/01/2006 15:04:05")
@@ -901,7 +808,7 @@ func mxDeviceWriteHeader(out *bufio.Writer, fName string) error {
 	return err
 }
 
-func mxDeviceWritePeripheralCfg(out *bufio.Writer, peripheral string, vmode string, freq string, i2cInfo map[string]string, usbHandle string, mciMode string, pins map[string]PinDefinition) error {
+func mxDeviceWritePeripheralCfg(out *bufio.Writer, peripheral string, vmode string, freq string, i2cInfo map[string]string, usbHandle string, mciMode string, sections []mxSectionType, pins map[string]PinDefinition) error {
 	var err error
 
 	str := "\n/*------------------------------ " + peripheral
@@ -980,6 +887,19 @@ func mxDeviceWritePeripheralCfg(out *bufio.Writer, peripheral string, vmode stri
 			return err
 		}
 	}
+	for _, section := range sections {
+		if _, err = out.WriteString("/* " + section.title + " */\n"); err != nil {
+			return err
+		}
+		for _, define := range section.defines {
+			if err = writeDefine(out, define.name, define.value); err != nil {
+				return err
+			}
+		}
+		if _, err = out.WriteString("\n"); err != nil {
+			return err
+		}
+	}
 	if len(pins) != 0 {
 		if _, err = out.WriteString("/* Pins */\n"); err != nil {
 			return err
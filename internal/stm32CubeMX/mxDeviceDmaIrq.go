@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
+)
+
+// getNVIC returns the NVIC settings of the context, e.g. "NVIC1.USART1_IRQn=true\:5\:0\:..."
+func getNVIC(iocData *ioc.Ioc, context string) map[string]string {
+	if context != "" {
+		if contextData, ok := iocData.Context(context); ok {
+			for _, ip := range contextData.IPs {
+				if strings.HasPrefix(ip, "NVIC") {
+					return iocData.Section(ip)
+				}
+			}
+		}
+	}
+	return iocData.Section("NVIC")
+}
+
+// getDMASection returns the DMA streams/channels used by the peripheral,
+// e.g. "Dma.USART1_RX.0.Instance=DMA2_Stream2" gives MX_USART1_DMA_RX_*
+func getDMASection(iocData *ioc.Ioc, peripheral string, nvic map[string]string) mxSectionType {
+	section := mxSectionType{title: "DMA"}
+	add := func(name string, value string) {
+		if value != "" {
+			section.defines = append(section.defines, mxDefineType{name, value})
+		}
+	}
+	dma := iocData.Section("Dma")
+	done := make(map[string]bool)
+	for _, key := range sortedKeys(dma) {
+		request, field, found := strings.Cut(key, ".")
+		if !found || !strings.HasSuffix(field, ".Instance") {
+			continue
+		}
+		direction, ok := getDMADirection(request, peripheral)
+		if !ok || done[direction] {
+			continue
+		}
+		done[direction] = true
+
+		prefix := peripheral + "_DMA"
+		if direction != "" {
+			prefix += "_" + direction
+		}
+		params := request + "." + strings.TrimSuffix(field, "Instance")
+		instance := dma[key]
+		controller, stream, _ := strings.Cut(instance, "_")
+		add(prefix+"_INSTANCE", instance)
+		add(prefix+"_CONTROLLER", controller)
+		if number, found := strings.CutPrefix(stream, "Stream"); found {
+			add(prefix+"_STREAM", number)
+			add(prefix+"_CHANNEL", dma[params+"Channel"]) // request selection of F2/F4/F7
+		} else if number, found := strings.CutPrefix(stream, "Channel"); found {
+			add(prefix+"_CHANNEL", number)
+		}
+		add(prefix+"_REQUEST", dma[params+"Request"])
+		add(prefix+"_DIRECTION", dma[params+"Direction"])
+		add(prefix+"_MODE", dma[params+"Mode"])
+		add(prefix+"_PRIORITY", dma[params+"Priority"])
+		if enabled, priority, subPriority := getIRQPriority(nvic[instance+"_IRQn"]); enabled {
+			add(prefix+"_IRQn", instance+"_IRQn")
+			add(prefix+"_IRQ_PRIORITY", priority)
+			add(prefix+"_IRQ_SUBPRIORITY", subPriority)
+		}
+	}
+	return section
+}
+
+// getDMADirection returns the part of the DMA request name following the peripheral,
+// e.g. "RX" for USART1_RX, "" if the request is named after the peripheral (ADC1)
+func getDMADirection(request string, peripheral string) (string, bool) {
+	if request == peripheral {
+		return "", true
+	}
+	return strings.CutPrefix(request, peripheral+"_")
+}
+
+// getIRQSection returns the enabled interrupts of the peripheral,
+// e.g. "NVIC.I2C1_EV_IRQn=true\:5\:0\:..." gives MX_I2C1_IRQ_EV*
+func getIRQSection(nvic map[string]string, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Interrupts"}
+	for _, key := range sortedKeys(nvic) {
+		irq, found := strings.CutSuffix(key, "_IRQn")
+		if !found {
+			continue
+		}
+		name, ok := getIRQName(irq, peripheral)
+		if !ok {
+			continue
+		}
+		enabled, priority, subPriority := getIRQPriority(nvic[key])
+		if !enabled {
+			continue
+		}
+		section.defines = append(section.defines,
+			mxDefineType{name, key},
+			mxDefineType{name + "_PRIORITY", priority},
+			mxDefineType{name + "_SUBPRIORITY", subPriority})
+	}
+	return section
+}
+
+// getIRQName checks whether the interrupt belongs to the peripheral and returns the macro name,
+// e.g. I2C1_EV -> I2C1_IRQ_EV, OTG_FS -> USB_OTG_FS_IRQ, TIM6_DAC -> DAC1_IRQ_TIM6_DAC
+func getIRQName(irq string, peripheral string) (string, bool) {
+	tokens := strings.Split(irq, "_")
+	expanded := make([]string, len(tokens))
+	for i, token := range tokens {
+		expanded[i] = token
+		if i > 0 && token != "" && getDigitAtEnd(token) == token {
+			// shared interrupts, e.g. ADC1_2 or EXTI15_10
+			prev := expanded[i-1]
+			if family := strings.TrimRight(prev, "0123456789"); family != prev {
+				expanded[i] = family + token
+			}
+		}
+	}
+
+	names := []string{peripheral}
+	if rest, found := strings.CutPrefix(peripheral, "USB_OTG_"); found {
+		names = append(names, "OTG_"+rest) // USB_OTG_FS uses OTG_FS_IRQn
+	}
+	for _, name := range names {
+		nameTokens := strings.Split(name, "_")
+		for i := 0; i+len(nameTokens) <= len(expanded); i++ {
+			if strings.Join(expanded[i:i+len(nameTokens)], "_") != name {
+				continue
+			}
+			rest := tokens[i+len(nameTokens):]
+			if i > 0 || (len(rest) > 0 && getDigitAtEnd(rest[0]) == rest[0]) {
+				return peripheral + "_IRQ_" + irq, true
+			}
+			if len(rest) == 0 {
+				return peripheral + "_IRQ", true
+			}
+			return peripheral + "_IRQ_" + strings.Join(rest, "_"), true
+		}
+	}
+
+	family := strings.TrimRight(peripheral, "0123456789")
+	for _, token := range expanded {
+		if family != peripheral && token == family {
+			return peripheral + "_IRQ_" + irq, true // shared without index, e.g. ADC or TIM6_DAC
+		}
+		if family == peripheral {
+			if index, found := strings.CutPrefix(token, peripheral); found && index != "" && getDigitAtEnd(index) == index {
+				return peripheral + "_IRQ_" + irq, true // peripheral without index, e.g. DMA1_Stream0 for DMA
+			}
+		}
+	}
+	return "", false
+}
+
+// getIRQPriority decodes a NVIC setting "enabled:preemption priority:sub priority:..."
+func getIRQPriority(value string) (bool, string, string) {
+	fields := strings.Split(value, ":")
+	if len(fields) < 3 || fields[0] != "true" {
+		return false, "", ""
+	}
+	return true, fields[1], fields[2]
+}
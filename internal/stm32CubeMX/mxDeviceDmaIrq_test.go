@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getIRQName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		irq        string
+		peripheral string
+		want       string
+		ok         bool
+	}{
+		{"USART1", "USART1", "USART1_IRQ", true},
+		{"I2C1_EV", "I2C1", "I2C1_IRQ_EV", true},
+		{"I2C1_ER", "I2C1", "I2C1_IRQ_ER", true},
+		{"OTG_FS", "USB_OTG_FS", "USB_OTG_FS_IRQ", true},
+		{"ADC1_2", "ADC2", "ADC2_IRQ_ADC1_2", true},
+		{"ADC1_2", "ADC1", "ADC1_IRQ_ADC1_2", true},
+		{"ADC", "ADC3", "ADC3_IRQ_ADC", true},
+		{"TIM6_DAC", "DAC1", "DAC1_IRQ_TIM6_DAC", true},
+		{"TIM6_DAC", "TIM6", "TIM6_IRQ_DAC", true},
+		{"TIM1_UP_TIM10", "TIM10", "TIM10_IRQ_TIM1_UP_TIM10", true},
+		{"DMA1_Stream0", "DMA", "DMA_IRQ_DMA1_Stream0", true},
+		{"USART1", "USART2", "", false},
+		{"USART10", "USART1", "", false},
+		{"TIM1_UP_TIM10", "TIM1", "TIM1_IRQ_UP_TIM10", true},
+		{"EXTI15_10", "EXTI", "EXTI_IRQ_EXTI15_10", true},
+		{"SPI1", "SPI2", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := getIRQName(tt.irq, tt.peripheral)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("getIRQName(%v, %v) = %v, %v, want %v, %v", tt.irq, tt.peripheral, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func Test_getIRQSection(t *testing.T) {
+	t.Parallel()
+
+	nvic := map[string]string{
+		"I2C1_EV_IRQn":   "true:5:0:true:false:true:true:true:true",
+		"I2C1_ER_IRQn":   "false:0:0:false:false:true:true:true:true",
+		"USART1_IRQn":    "true:2:1:true:false:true:true:true:true",
+		"PriorityGroup":  "NVIC_PRIORITYGROUP_4",
+		"SysTick_IRQn":   "true:15:0:false:false:true:false:true:false",
+		"I2C1_EV_IRQn.x": "true:1:1",
+	}
+	got := getIRQSection(nvic, "I2C1")
+	want := mxSectionType{title: "Interrupts", defines: []mxDefineType{
+		{"I2C1_IRQ_EV", "I2C1_EV_IRQn"},
+		{"I2C1_IRQ_EV_PRIORITY", "5"},
+		{"I2C1_IRQ_EV_SUBPRIORITY", "0"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getIRQSection() = %v, want %v", got, want)
+	}
+}
+
+func Test_getDMASection(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "Dma.USART1_RX.0.Instance=DMA2_Stream2\nDma.USART1_RX.0.Direction=DMA_PERIPH_TO_MEMORY\n"+
+		"Dma.USART1_RX.0.Mode=DMA_NORMAL\nDma.USART1_RX.0.Priority=DMA_PRIORITY_LOW\nDma.USART1_RX.0.Channel=DMA_CHANNEL_4\n"+
+		"Dma.USART1_TX.1.Instance=DMA1_Channel3\nDma.USART1_TX.1.Request=DMA_REQUEST_USART1_TX\n"+
+		"Dma.ADC1.2.Instance=DMA1_Channel1\n"+
+		"Dma.USART10_RX.3.Instance=DMA1_Channel2\n"+
+		"NVIC.DMA2_Stream2_IRQn=true\\:6\\:1\\:false\\:false\\:true\\:false\\:true\\:true\n"+
+		"NVIC.DMA1_Channel3_IRQn=false\\:0\\:0\\:false\\:false\\:true\\:false\\:true\\:true\n")
+	nvic := getNVIC(iocData, "")
+
+	got := getDMASection(iocData, "USART1", nvic)
+	want := mxSectionType{title: "DMA", defines: []mxDefineType{
+		{"USART1_DMA_RX_INSTANCE", "DMA2_Stream2"},
+		{"USART1_DMA_RX_CONTROLLER", "DMA2"},
+		{"USART1_DMA_RX_STREAM", "2"},
+		{"USART1_DMA_RX_CHANNEL", "DMA_CHANNEL_4"},
+		{"USART1_DMA_RX_DIRECTION", "DMA_PERIPH_TO_MEMORY"},
+		{"USART1_DMA_RX_MODE", "DMA_NORMAL"},
+		{"USART1_DMA_RX_PRIORITY", "DMA_PRIORITY_LOW"},
+		{"USART1_DMA_RX_IRQn", "DMA2_Stream2_IRQn"},
+		{"USART1_DMA_RX_IRQ_PRIORITY", "6"},
+		{"USART1_DMA_RX_IRQ_SUBPRIORITY", "1"},
+		{"USART1_DMA_TX_INSTANCE", "DMA1_Channel3"},
+		{"USART1_DMA_TX_CONTROLLER", "DMA1"},
+		{"USART1_DMA_TX_CHANNEL", "3"},
+		{"USART1_DMA_TX_REQUEST", "DMA_REQUEST_USART1_TX"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getDMASection(USART1) = %v, want %v", got, want)
+	}
+
+	got = getDMASection(iocData, "ADC1", nvic)
+	want = mxSectionType{title: "DMA", defines: []mxDefineType{
+		{"ADC1_DMA_INSTANCE", "DMA1_Channel1"},
+		{"ADC1_DMA_CONTROLLER", "DMA1"},
+		{"ADC1_DMA_CHANNEL", "1"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getDMASection(ADC1) = %v, want %v", got, want)
+	}
+}
+
+func Test_getNVIC(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "Mcu.Context0=CortexM7\nMcu.Context1=CortexM4\n"+
+		"CortexM7.IPs=RCC,NVIC1,USART1\nCortexM4.IPs=RCC\\:I,NVIC2\n"+
+		"NVIC1.USART1_IRQn=true\\:5\\:0\nNVIC2.SPI1_IRQn=true\\:3\\:0\nNVIC.I2C1_EV_IRQn=true\\:1\\:0\n")
+
+	tests := map[string]string{
+		"CortexM7": "USART1_IRQn",
+		"CortexM4": "SPI1_IRQn",
+		"":         "I2C1_EV_IRQn",
+	}
+	for context, want := range tests {
+		nvic := getNVIC(iocData, context)
+		if _, ok := nvic[want]; !ok || len(nvic) != 1 {
+			t.Errorf("getNVIC(%v) = %v, want %v", context, nvic, want)
+		}
+	}
+}
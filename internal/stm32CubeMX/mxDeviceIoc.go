@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
+)
+
+// MXDeviceSource selects where the values written to MX_Device.h are taken from
+type MXDeviceSource string
+
+const (
+	// MXDeviceFromIoc takes the values from the .ioc file and scans the
+	// generated sources only for values that are missing in the .ioc file
+	MXDeviceFromIoc MXDeviceSource = "ioc"
+	// MXDeviceFromSources scans main.c, *_hal_msp.c and the peripheral sources
+	MXDeviceFromSources MXDeviceSource = "sources"
+)
+
+// ParseMXDeviceSource checks the mx-device option
+func ParseMXDeviceSource(source string) (MXDeviceSource, error) {
+	switch MXDeviceSource(source) {
+	case MXDeviceFromIoc, MXDeviceFromSources:
+		return MXDeviceSource(source), nil
+	}
+	return "", errors.New("unknown MX_Device.h source '" + source + "', use 'ioc' or 'sources'")
+}
+
+// readPins returns the pin definitions of the peripheral
+func readPins(mode MXDeviceSource, iocData *ioc.Ioc, fSrc *os.File, peripheral string) (map[string]PinDefinition, error) {
+	if mode != MXDeviceFromIoc {
+		if fSrc == nil {
+			return nil, nil
+		}
+		return getPins(iocData, fSrc, peripheral)
+	}
+	return getPinsFromIoc(iocData, fSrc, peripheral)
+}
+
+// readI2cInfo returns the I2C filter settings of the peripheral
+func readI2cInfo(mode MXDeviceSource, iocData *ioc.Ioc, fSrc *os.File, peripheral string) (map[string]string, error) {
+	info := make(map[string]string)
+	if mode == MXDeviceFromIoc {
+		info = getI2cInfoFromIoc(iocData, peripheral)
+		if len(info) == 2 {
+			return info, nil
+		}
+	}
+	if fSrc == nil {
+		return info, nil
+	}
+	scanned, err := getI2cInfo(fSrc, peripheral)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range scanned {
+		if _, ok := info[key]; !ok {
+			info[key] = value
+		}
+	}
+	return info, nil
+}
+
+// readUSBHandle returns the HAL handle of the USB peripheral
+func readUSBHandle(mode MXDeviceSource, iocData *ioc.Ioc, fSrc *os.File, peripheral string) (string, error) {
+	if mode == MXDeviceFromIoc {
+		if handle := getUSBHandleFromIoc(iocData, peripheral); handle != "" {
+			return handle, nil
+		}
+	}
+	if fSrc == nil {
+		return "", nil
+	}
+	return getUSBHandle(fSrc, peripheral)
+}
+
+// readMCIMode returns the card type (SD or MMC) of the SDMMC/SDIO peripheral
+func readMCIMode(mode MXDeviceSource, iocData *ioc.Ioc, fSrc *os.File, peripheral string) (string, error) {
+	if mode == MXDeviceFromIoc {
+		if mode := getMCIModeFromIoc(iocData, peripheral); mode != "" {
+			return mode, nil
+		}
+	}
+	if fSrc == nil {
+		return "", nil
+	}
+	return getMCIMode(fSrc, peripheral)
+}
+
+// getPinsFromIoc takes the pins of the peripheral from the signals in the .ioc file.
+// GPIO settings missing in the .ioc file (e.g. the alternate function or values
+// left at their default) are taken from the HAL_GPIO_Init call in the source.
+func getPinsFromIoc(iocData *ioc.Ioc, fSrc *os.File, peripheral string) (map[string]PinDefinition, error) {
+	pinsInfo := make(map[string]PinDefinition)
+	for key, pinData := range iocData.Pins {
+		signal, ok := peripheralSignal(pinData.Signal, peripheral)
+		if strings.HasPrefix(key, "VP") || !ok {
+			continue
+		}
+		info, ok := getPinFromIoc(key, pinData)
+		if !ok {
+			continue
+		}
+		if fSrc != nil && (info.mode == "" || info.pull == "" || info.speed == "" || info.alternate == "") {
+			label := pinData.Label
+			if label != "" {
+				label = strings.Split(label, "[")[0]
+				label = strings.TrimRight(label, " ")
+				label = replaceSpecialChars(label, "_")
+				label = strings.ReplaceAll(label, ".", "_")
+			}
+			scanned, err := getPinConfiguration(fSrc, peripheral, info.p, label)
+			if err != nil {
+				return nil, err
+			}
+			if scanned.port == "" {
+				continue // pin is not initialized by the generated code
+			}
+			info.mode = firstNonEmpty(info.mode, scanned.mode)
+			info.pull = firstNonEmpty(info.pull, scanned.pull)
+			info.speed = firstNonEmpty(info.speed, scanned.speed)
+			info.alternate = firstNonEmpty(info.alternate, scanned.alternate)
+		}
+		pinsInfo[signal] = info
+	}
+	return pinsInfo, nil
+}
+
+// getPinFromIoc returns the GPIO settings of a pin stored in the .ioc file
+func getPinFromIoc(key string, pinData ioc.PinType) (PinDefinition, bool) {
+	p := strings.Split(key, "(")[0]
+	p = strings.Split(p, " ")[0]
+	p = strings.Split(p, "_")[0]
+	p = strings.Split(p, "-")[0]
+
+	pinNum := getDigitAtEnd(p)
+	if pinNum == "" || !strings.HasPrefix(p, "P") {
+		return PinDefinition{}, false
+	}
+	info := PinDefinition{
+		p:         p,
+		pin:       "GPIO_PIN_" + pinNum,
+		port:      "GPIO" + strings.TrimSuffix(strings.TrimPrefix(p, "P"), pinNum),
+		mode:      firstNonEmpty(pinData.GPIO["GPIO_Mode"], pinData.GPIO["GPIO_ModeDefaultPP"], pinData.GPIO["GPIO_ModeDefaultOD"], pinData.GPIO["GPIO_ModeDefaultEXTI"]),
+		pull:      firstNonEmpty(pinData.GPIO["GPIO_Pu"], pinData.GPIO["GPIO_PuPd"]), // I2C pins, GPIO_PuPd keeps the default then
+		speed:     pinData.GPIO["GPIO_Speed"],
+		alternate: pinData.GPIO["GPIO_AF"],
+	}
+	return info, true
+}
+
+// peripheralSignal returns the signal name without the "S_" prefix of shared timer
+// signals and whether the signal belongs to the peripheral (TIM1 but not TIM12)
+func peripheralSignal(signal string, peripheral string) (string, bool) {
+	name := strings.TrimPrefix(signal, "S_")
+	rest, found := strings.CutPrefix(name, peripheral)
+	if !found || (rest != "" && rest[0] >= '0' && rest[0] <= '9') {
+		return name, false
+	}
+	return name, true
+}
+
+// getI2cInfoFromIoc returns the filter settings stored in the .ioc file
+func getI2cInfoFromIoc(iocData *ioc.Ioc, peripheral string) map[string]string {
+	info := make(map[string]string)
+	if !strings.HasPrefix(peripheral, "I2C") {
+		return info
+	}
+	params := iocData.Section(peripheral)
+	switch params["Analog_Filter"] {
+	case "I2C_ANALOGFILTER_ENABLE":
+		info["ANF_ENABLE"] = "1"
+	case "I2C_ANALOGFILTER_DISABLE":
+		info["ANF_ENABLE"] = "0"
+	}
+	for _, key := range []string{"Digital_Filter", "DigitalFilter"} {
+		if dnf, ok := params[key]; ok {
+			info["DNF"] = dnf
+			break
+		}
+	}
+	return info
+}
+
+// getUSBHandleFromIoc derives the handle of an USB OTG peripheral from its mode
+func getUSBHandleFromIoc(iocData *ioc.Ioc, peripheral string) string {
+	if !strings.HasPrefix(peripheral, "USB_OTG") {
+		return "" // handle names of the other USB peripherals differ from the IP name
+	}
+	mode := iocData.IPs[peripheral].VirtualMode
+	switch {
+	case strings.HasPrefix(mode, "Device"):
+		return "hpcd_" + peripheral
+	case strings.HasPrefix(mode, "Host"):
+		return "hhcd_" + peripheral
+	}
+	return ""
+}
+
+// getMCIModeFromIoc derives the card type from the mode of the SDMMC/SDIO pins
+func getMCIModeFromIoc(iocData *ioc.Ioc, peripheral string) string {
+	if !strings.HasPrefix(peripheral, "SDMMC") && !strings.HasPrefix(peripheral, "SDIO") {
+		return ""
+	}
+	for _, pinData := range iocData.Pins {
+		if !strings.HasPrefix(pinData.Signal, peripheral+"_") {
+			continue
+		}
+		mode := strings.ToLower(pinData.Mode)
+		switch {
+		case strings.HasPrefix(mode, "mmc"):
+			return "MMC"
+		case strings.HasPrefix(mode, "sd"):
+			return "SD"
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
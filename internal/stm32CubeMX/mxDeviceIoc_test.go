@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseMXDeviceSource(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []string{"ioc", "sources"} {
+		if got, err := ParseMXDeviceSource(source); err != nil || string(got) != source {
+			t.Errorf("ParseMXDeviceSource(%v) = %v, %v", source, got, err)
+		}
+	}
+	if _, err := ParseMXDeviceSource("main.c"); err == nil {
+		t.Errorf("ParseMXDeviceSource() error = nil for unknown source")
+	}
+}
+
+func Test_getPinsFromIoc(t *testing.T) {
+	iocData := parseIoc(t, "PB8.GPIO_Label=SCL [Arduino]\nPB8.GPIO_Mode=GPIO_MODE_AF_OD\nPB8.GPIO_Pu=GPIO_PULLUP\nPB8.GPIO_PuPd=GPIO_NOPULL\nPB8.Signal=I2C1_SCL\n"+
+		"PB9.GPIO_Speed=GPIO_SPEED_FREQ_HIGH\nPB9.Signal=I2C1_SDA\n"+
+		"PB10.Signal=I2C1_SMBA\n"+
+		"VP_I2C1_VS.Signal=I2C1_VS\n"+
+		"PA2.Signal=USART2_TX\n")
+
+	// .ioc only
+	got, err := getPinsFromIoc(iocData, nil, "I2C1")
+	if err != nil {
+		t.Fatalf("getPinsFromIoc() error = %v", err)
+	}
+	want := map[string]PinDefinition{
+		"I2C1_SCL":  {p: "PB8", pin: "GPIO_PIN_8", port: "GPIOB", mode: "GPIO_MODE_AF_OD", pull: "GPIO_PULLUP"},
+		"I2C1_SDA":  {p: "PB9", pin: "GPIO_PIN_9", port: "GPIOB", speed: "GPIO_SPEED_FREQ_HIGH"},
+		"I2C1_SMBA": {p: "PB10", pin: "GPIO_PIN_10", port: "GPIOB"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getPinsFromIoc() = %v, want %v", got, want)
+	}
+
+	// missing values from the source, pins not initialized there are skipped
+	file, err := os.Open("../../testdata/stm32cubemx/test_msp.c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	got, err = getPinsFromIoc(iocData, file, "I2C1")
+	if err != nil {
+		t.Fatalf("getPinsFromIoc() error = %v", err)
+	}
+	want = map[string]PinDefinition{
+		"I2C1_SCL": {p: "PB8", pin: "GPIO_PIN_8", port: "GPIOB", mode: "GPIO_MODE_AF_OD", pull: "GPIO_PULLUP", speed: "GPIO_SPEED_FREQ_LOW", alternate: "GPIO_AF4_I2C1"},
+		"I2C1_SDA": {p: "PB9", pin: "GPIO_PIN_9", port: "GPIOB", mode: "GPIO_MODE_AF_OD", pull: "GPIO_NOPULL", speed: "GPIO_SPEED_FREQ_HIGH", alternate: "GPIO_AF4_I2C1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getPinsFromIoc() with source = %v, want %v", got, want)
+	}
+}
+
+func Test_readI2cInfo(t *testing.T) {
+	t.Parallel()
+
+	complete := parseIoc(t, "I2C1.Analog_Filter=I2C_ANALOGFILTER_DISABLE\nI2C1.Digital_Filter=3\n")
+	partial := parseIoc(t, "I2C1.Analog_Filter=I2C_ANALOGFILTER_DISABLE\n")
+	empty := parseIoc(t, "I2C1.Timing=0x30909DEC\n")
+
+	file, err := os.Open("../../testdata/stm32cubemx/main.c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		name string
+		ioc  bool
+		file *os.File
+		want map[string]string
+	}{
+		{"ioc only", true, nil, map[string]string{"ANF_ENABLE": "0", "DNF": "3"}},
+		{"ioc complete", true, file, map[string]string{"ANF_ENABLE": "0", "DNF": "3"}},
+		{"ioc partial", true, file, map[string]string{"ANF_ENABLE": "0", "DNF": "0"}},
+		{"ioc empty", true, file, map[string]string{"ANF_ENABLE": "1", "DNF": "0"}},
+		{"sources", false, file, map[string]string{"ANF_ENABLE": "1", "DNF": "0"}},
+	}
+	for _, tt := range tests {
+		iocData := complete
+		switch tt.name {
+		case "ioc partial":
+			iocData = partial
+		case "ioc empty", "sources":
+			iocData = empty
+		}
+		mode := MXDeviceFromSources
+		if tt.ioc {
+			mode = MXDeviceFromIoc
+		}
+		got, err := readI2cInfo(mode, iocData, tt.file, "I2C1")
+		if err != nil {
+			t.Errorf("readI2cInfo() %s error = %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("readI2cInfo() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_getUSBHandleFromIoc(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "Mcu.IP0=USB_OTG_FS\nMcu.IP1=USB_OTG_HS\nMcu.IP2=USB\nMcu.IP3=USB_OTG_HS2\n"+
+		"USB_OTG_FS.VirtualMode=Host_Only\nUSB_OTG_HS.VirtualMode-Device_HS=Device_HS\nUSB.VirtualMode=Device_Only\n")
+
+	tests := map[string]string{
+		"USB_OTG_FS":  "hhcd_USB_OTG_FS",
+		"USB_OTG_HS":  "hpcd_USB_OTG_HS",
+		"USB":         "",
+		"USB_OTG_HS2": "",
+		"SPI1":        "",
+	}
+	for peripheral, want := range tests {
+		if got := getUSBHandleFromIoc(iocData, peripheral); got != want {
+			t.Errorf("getUSBHandleFromIoc(%v) = %v, want %v", peripheral, got, want)
+		}
+	}
+}
+
+func Test_getMCIModeFromIoc(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "PC8.Mode=mmc_8_bits_Wide_bus\nPC8.Signal=SDMMC1_D0\n"+
+		"PD6.Mode=SD_4_bits_Wide_bus\nPD6.Signal=SDMMC2_D0\n"+
+		"PC12.Mode=SD_1_bit\nPC12.Signal=SDIO_CK\n")
+
+	tests := map[string]string{
+		"SDMMC1": "MMC",
+		"SDMMC2": "SD",
+		"SDIO":   "SD",
+		"SDMMC3": "",
+		"USART1": "",
+	}
+	for peripheral, want := range tests {
+		if got := getMCIModeFromIoc(iocData, peripheral); got != want {
+			t.Errorf("getMCIModeFromIoc(%v) = %v, want %v", peripheral, got, want)
+		}
+	}
+}
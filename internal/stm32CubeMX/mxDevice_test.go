@@ -17,6 +17,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
 )
 
 func Test_MXDeviceContexts(t *testing.T) {
@@ -91,7 +93,7 @@ func Test_ReadContexts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			argsSlice := []BridgeParamType{tt.params}
-			if err := ReadContexts(tt.iocFile, argsSlice); (err != nil) != tt.wantErr {
+			if err := ReadContexts(DefaultConfig(), tt.iocFile, argsSlice); (err != nil) != tt.wantErr {
 				t.Errorf("ReadContexts() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
 			if !tt.wantErr && len(argsSlice) > 0 && argsSlice[0].MainLocation != "Src" {
@@ -123,62 +125,32 @@ func Test_ReadContexts_NoPanicOnWalkFileInfoNil(t *testing.T) {
 		}
 	}()
 
-	err := ReadContexts(iocFile, params)
+	err := ReadContexts(DefaultConfig(), iocFile, params)
 	if err == nil {
 		t.Fatalf("ReadContexts() expected error for missing source folder")
 	}
 }
 
-func Test_createContextMap(t *testing.T) {
-	t.Parallel()
-
-	var parts = make(map[string]map[string]string)
-	parts["Mcu"] = map[string]string{"Family": "STM32U5"}
-	parts["PA10"] = map[string]string{"GPIOParameters": "GPIO_Label"}
-
-	type args struct {
-		iocFile string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    map[string]map[string]string
-		wantErr bool
-	}{
-		{"test.ioc", args{"../../testdata/stm32cubemx/test.ioc"}, parts, false},
-		{"nix", args{"xxx"}, nil, true},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got, err := createContextMap(tt.args.iocFile)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("createContextMap() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("createContextMap() %s got = %v, want %v", tt.name, got, tt.want)
-			}
-		})
+func parseIoc(t *testing.T, content string) *ioc.Ioc {
+	t.Helper()
+	iocData, err := ioc.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ioc.Parse() error = %v", err)
 	}
+	return iocData
 }
 
 func Test_writeMXdeviceH(t *testing.T) {
-	var mcuContext0 = make(map[string]map[string]string)
-	mcuContext0["Mcu"] = map[string]string{"nixIPs": "myContext"}
-	var mcuContext1 = make(map[string]map[string]string)
-	mcuContext1["Mcu"] = map[string]string{"IPs": "myContext"}
-	var mcuContext2 = make(map[string]map[string]string)
-	mcuContext2["Mcu"] = map[string]string{"IP1": "SPI2"}
-	mcuContext2["SPI2"] = map[string]string{"VirtualModex": "ModeX"}
-	mcuContext2["P1"] = map[string]string{"Signal": "SPI2"}
+	mcuContext0 := parseIoc(t, "Mcu.nixIPs=myContext\n")
+	mcuContext1 := parseIoc(t, "Mcu.Context0=myContext\nMcu.IP0=SPI2\nmyContext.IPs=SPI2\n")
+	mcuContext2 := parseIoc(t, "Mcu.IP1=SPI2\nSPI2.VirtualModex=ModeX\nP1.Signal=SPI2\n")
 
 	type args struct {
-		contextMap map[string]map[string]string
-		srcFolder  string
-		mspName    string
-		cfgPath    string
-		context    string
+		iocData   *ioc.Ioc
+		srcFolder string
+		mspName   string
+		cfgPath   string
+		context   string
 	}
 	tests := []struct {
 		name    string
@@ -187,114 +159,57 @@ func Test_writeMXdeviceH(t *testing.T) {
 	}{
 		{"Mcu", args{mcuContext2, "../../testdata/stm32cubemx", "test_msp.c", "../../testdata/stm32cubemx/cfg", ""}, false},
 		{"wrong context", args{mcuContext0, "../../testdata/stm32cubemx", "test_msp.c", "../../testdata/stm32cubemx/cfg", "context"}, true},
-		{"Mcu Context", args{mcuContext1, "../../testdata/stm32cubemx", "test_msp.c", "../../testdata/stm32cubemx/cfg", "Mcu"}, false},
+		{"Mcu Context", args{mcuContext1, "../../testdata/stm32cubemx", "test_msp.c", "../../testdata/stm32cubemx/cfg", "myContext"}, false},
 		{"wrong myContext", args{mcuContext1, "../../testdata/stm32cubemx", "test_msp.c", "../../testdata/stm32cubemx/cfg", "context"}, true},
 		{"wrong msp", args{mcuContext1, "", "msp", "../../testdata/stm32cubemx/cfg", "context"}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			defer os.RemoveAll(tt.args.srcFolder + "/../" + tt.args.cfgPath)
-			if err := writeMXdeviceH(tt.args.contextMap, tt.args.srcFolder, tt.args.mspName, tt.args.cfgPath, tt.args.context, "test.cgen.yml"); (err != nil) != tt.wantErr {
+			if err := writeMXdeviceH(MXDeviceFromIoc, tt.args.iocData, tt.args.srcFolder, tt.args.mspName, tt.args.cfgPath, tt.args.context, "test.cgen.yml"); (err != nil) != tt.wantErr {
 				t.Errorf("writeMXdeviceH() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_getContexts(t *testing.T) {
+func Test_getPeripherals(t *testing.T) {
 	t.Parallel()
 
-	var mcuContext1 = make(map[string]map[string]string)
-	mcuContext1["Mcu"] = map[string]string{"ContextTest": "myContext"}
-
-	var mcuContext2 = make(map[string]map[string]string)
-	mcuContext2["Mcu"] = map[string]string{"Context1": "myContext1", "Context2": "myContext2"}
-
-	var oneEmpty = make(map[int]string)
-	oneEmpty[0] = ""
-
-	var two1 = make(map[int]string)
-	two1[1] = "myContext1"
-	two1[2] = "myContext2"
-
-	var two2 = make(map[int]string)
-	two2[2] = "myContext1"
-	two2[1] = "myContext2"
+	parts := parseIoc(t, "Mcu.Context0=Context1\nMcu.IP1=UART5\nMcu.IP2=xx\nMcu.IP3=USB\nMcu.IP4=SPI2\n"+
+		"Context1.IPs=CORTEX_M4\\:I,UART5\\:I,USB\\:I,OCTOSPI2\n")
+	parts1 := parseIoc(t, "ccc.x=y\n")
+	parts2 := parseIoc(t, "Mcu.Context0=Context1\nContext1.xxx=jdsfhkha\n")
+	parts3 := parseIoc(t, "Context1.IPs=CORTEX_M4\\:I,UART5\\:I,USB_DEVICE_M4\\:I\n")
 
 	type args struct {
-		contextMap map[string]map[string]string
+		iocData *ioc.Ioc
+		context string
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want1   map[int]string
-		want2   map[int]string
+		want    []string
 		wantErr bool
 	}{
-		{"1 Context", args{mcuContext1}, oneEmpty, oneEmpty, false},
-		{"2 Contexts", args{mcuContext2}, two1, two2, false},
+		{"test", args{parts, "Context1"}, []string{"UART5", "USB"}, false},
+		{"fail1", args{parts1, "xxx"}, nil, true},
+		{"fail2", args{parts2, "Context1"}, nil, true},
+		{"fail3", args{parts3, "Context1"}, nil, true},
+		{"test1", args{parts, ""}, []string{"UART5", "USB", "SPI2"}, false},
+		{"fail4", args{parts1, ""}, nil, true},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := getContexts(tt.args.contextMap)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("getContexts() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want1) && !reflect.DeepEqual(got, tt.want2) {
-				t.Errorf("getContexts() %s = %v, want %v", tt.name, got, tt.want1)
-			}
-		})
-	}
-}
-
-func Test_getPeripherals(t *testing.T) {
-	//	t.Parallel()
-
-	var parts = make(map[string]map[string]string)
-	parts["Mcu"] = map[string]string{"IP1": "UART", "IP2": "xx", "IP3": "USB"}
-	parts["Context1"] = map[string]string{"IPs": "CORTEX_M4\\:I,UART5\\:I,USB_DEVICE_M4\\:I"}
-
-	var parts1 = make(map[string]map[string]string)
-	parts1["ccc"] = map[string]string{}
-
-	var parts2 = make(map[string]map[string]string)
-	parts2["Context1"] = map[string]string{"xxx": "jdsfhkha"}
-
-	var parts3 = make(map[string]map[string]string)
-	parts3["Context1"] = map[string]string{"IPs": "CORTEX_M4\\:I,UART5\\:I,USB_DEVICE_M4\\:I"}
-
-	type args struct {
-		contextMap map[string]map[string]string
-		context    string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want1   []string
-		want2   []string
-		wantErr bool
-	}{
-		{"test", args{parts, "Context1"}, []string{"UART", "USB"}, []string{"USB", "UART"}, false},
-		{"fail1", args{parts1, "xxx"}, nil, nil, true},
-		{"fail2", args{parts2, "Context1"}, nil, nil, true},
-		{"fail3", args{parts3, "Context1"}, nil, nil, true},
-		{"test1", args{parts, ""}, []string{"UART", "USB"}, []string{"USB", "UART"}, false},
-		{"fail4", args{parts1, ""}, nil, nil, true},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			//			t.Parallel()
-			got, err := getPeripherals(tt.args.contextMap, tt.args.context)
+			got, err := getPeripherals(tt.args.iocData, tt.args.context)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getPeripherals() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want1) && !reflect.DeepEqual(got, tt.want2) {
-				t.Errorf("getPeripherals() %s = %v, want %v", tt.name, got, tt.want1)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPeripherals() %s = %v, want %v", tt.name, got, tt.want)
 			}
 		})
 	}
@@ -303,14 +218,11 @@ func Test_getPeripherals(t *testing.T) {
 func Test_getVirtualMode(t *testing.T) {
 	t.Parallel()
 
-	var parts = make(map[string]map[string]string)
-	parts["USB"] = map[string]string{"VirtualModexx": "vvmm"}
-
-	var parts1 = make(map[string]map[string]string)
-	parts1["USB"] = map[string]string{"xx": "vvmm"}
+	parts := parseIoc(t, "Mcu.IP0=USB\nUSB.VirtualModexx=vvmm\n")
+	parts1 := parseIoc(t, "Mcu.IP0=USB\nUSB.xx=vvmm\n")
 
 	type args struct {
-		contextMap map[string]map[string]string
+		iocData    *ioc.Ioc
 		peripheral string
 	}
 	tests := []struct {
@@ -325,7 +237,7 @@ func Test_getVirtualMode(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := getVirtualMode(tt.args.contextMap, tt.args.peripheral); got != tt.want {
+			if got := getVirtualMode(tt.args.iocData, tt.args.peripheral); got != tt.want {
 				t.Errorf("getVirtualMode() %s = %v, want %v", tt.name, got, tt.want)
 			}
 		})
@@ -333,15 +245,14 @@ func Test_getVirtualMode(t *testing.T) {
 }
 
 func Test_getPins(t *testing.T) {
-	var pins = make(map[string]map[string]string)
-	pins["PB8"] = map[string]string{"Mode": "MII", "Signal": "I2C1_sss", "GPIO_Label": "lab"}
+	pins := parseIoc(t, "PB8.Mode=MII\nPB8.Signal=I2C1_sss\nPB8.GPIO_Label=lab\n")
 
 	pindef := PinDefinition{p: "PB8", pin: "GPIO_PIN_8", port: "GPIOB", mode: "GPIO_MODE_AF_OD", pull: "GPIO_NOPULL", speed: "GPIO_SPEED_FREQ_LOW", alternate: "GPIO_AF4_I2C1"}
 	var pindefs = make(map[string]PinDefinition)
 	pindefs["I2C1_sss"] = pindef
 
 	type args struct {
-		contextMap map[string]map[string]string
+		iocData    *ioc.Ioc
 		filename   string
 		peripheral string
 	}
@@ -360,7 +271,7 @@ func Test_getPins(t *testing.T) {
 				t.Errorf("getPinConfiguration() %s cannot open %s", tt.name, tt.args.filename)
 				return
 			}
-			got, err := getPins(tt.args.contextMap, file, tt.args.peripheral)
+			got, err := getPins(tt.args.iocData, file, tt.args.peripheral)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getPins() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -610,7 +521,7 @@ func Test_mxDeviceWritePeripheralCfg(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.args.out = bufio.NewWriter(&b)
 
-			if err := mxDeviceWritePeripheralCfg(tt.args.out, tt.args.peripheral, tt.args.vmode, "", tt.args.i2cInfo, "", "", tt.args.pins); (err != nil) != tt.wantErr {
+			if err := mxDeviceWritePeripheralCfg(tt.args.out, tt.args.peripheral, tt.args.vmode, "", tt.args.i2cInfo, "", "", nil, tt.args.pins); (err != nil) != tt.wantErr {
 				t.Errorf("mxDeviceWritePeripheralCfg() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			tt.args.out.Flush()
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/ioc"
+)
+
+// mxPeripheralType describes an IP family written to MX_Device.h.
+// New IP families are added to mxPeripherals without touching writeMXdeviceH.
+type mxPeripheralType struct {
+	family  string                                                  // IP family, prefix of the IP names
+	match   *regexp.Regexp                                          // IP names of the family if the prefix is ambiguous
+	source  string                                                  // source of the family when generated as .c/.h pair
+	clocks  []string                                                // RCC clock names of the family, tried after the IP name
+	params  []mxParamType                                           // .ioc parameters written as macros
+	macros  func(iocData *ioc.Ioc, peripheral string) mxSectionType // additional macros
+	present func(iocData *ioc.Ioc, context string) bool             // pseudo peripheral not listed in Mcu.IPs
+	pins    func(iocData *ioc.Ioc, context string) map[string]PinDefinition
+}
+
+// mxParamType maps an IP parameter of the .ioc file to a macro name
+type mxParamType struct {
+	key  string
+	name string
+}
+
+// mxSectionType is a commented group of macros of a peripheral
+type mxSectionType struct {
+	title   string
+	defines []mxDefineType
+}
+
+type mxDefineType struct {
+	name  string
+	value string
+}
+
+// mxPeripherals lists the supported IP families, the first matching entry is taken
+var mxPeripherals = []mxPeripheralType{
+	{family: "USART", source: "usart.c"},
+	{family: "UART", source: "usart.c"},
+	{family: "LPUART", source: "usart.c"},
+	{family: "SPI", source: "spi.c"},
+	{family: "I2C", source: "i2c.c"},
+	{family: "ETH", source: "eth.c"},
+	{family: "SDMMC", source: "sdmmc.c"},
+	{family: "FDCAN", source: "fdcan.c"},
+	{family: "CAN", source: "can.c"},
+	{family: "USB_OTG", source: "usb_otg.c"},
+	{family: "USB", source: "usb.c"},
+	{family: "SDIO", source: "sdio.c"},
+	{
+		family: "QUADSPI", source: "quadspi.c", clocks: []string{"QSPI"},
+		params: []mxParamType{{"FlashSize", "FLASH_SIZE"}},
+	},
+	{
+		family: "OCTOSPI", match: regexp.MustCompile(`^OCTOSPI[0-9]*$`), source: "octospi.c", clocks: []string{"OCTOSPI", "OCTOSPIM"},
+		params: []mxParamType{{"DeviceSize", "DEVICE_SIZE"}, {"MemoryType", "MEMORY_TYPE"}},
+	},
+	{family: "SAI", match: regexp.MustCompile(`^SAI[0-9]*$`), source: "sai.c", clocks: []string{"SAI"}, macros: getSAIBlocks},
+	{
+		family: "I2S", match: regexp.MustCompile(`^I2S[0-9]*$`), source: "i2s.c", clocks: []string{"I2S"},
+		params: []mxParamType{{"Mode", "MODE"}, {"Standard", "STANDARD"}, {"DataFormat", "DATA_FORMAT"}, {"AudioFreq", "AUDIO_FREQ"}},
+	},
+	{family: "TIM", match: regexp.MustCompile(`^TIM[0-9]+$`), source: "tim.c", macros: getTIMChannels},
+	{family: "ADC", match: regexp.MustCompile(`^ADC[0-9]*$`), source: "adc.c", clocks: []string{"ADC"}, macros: getChannels},
+	{family: "DAC", match: regexp.MustCompile(`^DAC[0-9]*$`), source: "dac.c", clocks: []string{"DAC"}, macros: getChannels},
+	{family: "RTC", match: regexp.MustCompile(`^RTC$`), source: "rtc.c", clocks: []string{"RTC"}, macros: getRTCAlarms},
+	{family: "DMA", match: regexp.MustCompile(`^(DMA|BDMA|MDMA|GPDMA|LPDMA|HPDMA)[0-9]*$`), source: "dma.c", macros: getDMARequests},
+	{family: "EXTI", present: hasExtiPins, pins: getExtiPins},
+}
+
+// findMXPeripheral returns the IP family of a peripheral, nil if the peripheral is not supported
+func findMXPeripheral(peripheral string) *mxPeripheralType {
+	for i := range mxPeripherals {
+		entry := &mxPeripherals[i]
+		if entry.present != nil {
+			if peripheral == entry.family {
+				return entry
+			}
+			continue
+		}
+		if entry.match != nil {
+			if entry.match.MatchString(peripheral) {
+				return entry
+			}
+		} else if strings.HasPrefix(peripheral, entry.family) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// getMXSections returns the family specific macros, DMA and interrupt settings of a peripheral
+func getMXSections(iocData *ioc.Ioc, entry *mxPeripheralType, peripheral string, context string) []mxSectionType {
+	var sections []mxSectionType
+	if len(entry.params) > 0 {
+		section := mxSectionType{title: "Parameters"}
+		params := iocData.Section(peripheral)
+		for _, param := range entry.params {
+			if value := params[param.key]; value != "" {
+				section.defines = append(section.defines, mxDefineType{peripheral + "_" + param.name, value})
+			}
+		}
+		if len(section.defines) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	if entry.macros != nil {
+		if section := entry.macros(iocData, peripheral); len(section.defines) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	nvic := getNVIC(iocData, context)
+	for _, section := range []mxSectionType{getDMASection(iocData, peripheral, nvic), getIRQSection(nvic, peripheral)} {
+		if len(section.defines) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// getPeriphClockFreq takes the peripheral clock from the user constants or the RCC settings
+func getPeriphClockFreq(iocData *ioc.Ioc, peripheral string, clocks []string) string {
+	if len(clocks) == 0 {
+		return ""
+	}
+	if freq := getUserConstant(iocData, peripheral+"_PERIPH_CLOCK_FREQ"); freq != "" {
+		// Frequency defined by user in CubeMX
+		return freq
+	}
+	if freq, ok := iocData.RCC.Frequency(peripheral); ok {
+		return freq
+	}
+	digit := getDigitAtEnd(peripheral)
+	for _, clock := range clocks {
+		// shared clocks, e.g. "RCC.ADC345Freq_Value" for ADC4
+		for _, name := range iocData.RCC.FrequencyNames() {
+			if idx, found := strings.CutPrefix(name, clock); found && digit != "" && getDigitAtEnd(idx) == idx && strings.Contains(idx, digit) {
+				freq, _ := iocData.RCC.Frequency(name)
+				return freq
+			}
+		}
+		if freq, ok := iocData.RCC.Frequency(clock); ok {
+			return freq
+		}
+	}
+	return ""
+}
+
+// getSAIBlocks returns the virtual mode of each SAI block, e.g. "SAI2.VirtualMode-SAI_A_MasterWithClock=VM_MASTER"
+func getSAIBlocks(iocData *ioc.Ioc, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Blocks"}
+	params := iocData.Section(peripheral)
+	for _, key := range sortedKeys(params) {
+		mode, found := strings.CutPrefix(key, "VirtualMode-")
+		if !found {
+			continue
+		}
+		instance := params["Instance-"+mode] // SAI$Index_Block_A
+		_, block, found := strings.Cut(instance, "_Block_")
+		if !found {
+			continue
+		}
+		section.defines = append(section.defines, mxDefineType{peripheral + "_BLOCK_" + block + "_VM", params[key]})
+	}
+	return section
+}
+
+// getTIMChannels returns the mode of the timer channels, e.g. "TIM1.Channel-PWM Generation1 CH1=TIM_CHANNEL_1"
+func getTIMChannels(iocData *ioc.Ioc, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Channels"}
+	params := iocData.Section(peripheral)
+	for _, key := range sortedKeys(params) {
+		mode, found := strings.CutPrefix(key, "Channel-")
+		if !found {
+			continue
+		}
+		channel, found := strings.CutPrefix(params[key], "TIM_CHANNEL_")
+		if !found {
+			continue
+		}
+		mode, _, _ = strings.Cut(mode, " CH")
+		mode = strings.TrimRight(mode, "0123456789")
+		section.defines = append(section.defines, mxDefineType{peripheral + "_CH" + channel + "_MODE", mode})
+	}
+	return section
+}
+
+// getChannels returns the converter channels in use, e.g. "ADC3.Channel-IN4=ADC_CHANNEL_4"
+func getChannels(iocData *ioc.Ioc, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Channels"}
+	family := strings.TrimRight(peripheral, "0123456789")
+	channels := make(map[string]bool)
+	for _, value := range iocData.Section(peripheral) {
+		if channel, found := strings.CutPrefix(value, family+"_CHANNEL_"); found {
+			channels[channel] = true
+		}
+	}
+	for _, channel := range sortedKeys(channels) {
+		section.defines = append(section.defines, mxDefineType{peripheral + "_CHANNEL_" + channel, "1"})
+	}
+	return section
+}
+
+// getRTCAlarms returns the RTC alarms in use, e.g. "RTC.Alarm-Alarm A=RTC_ALARM_A"
+func getRTCAlarms(iocData *ioc.Ioc, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Alarms"}
+	alarms := make(map[string]bool)
+	for _, value := range iocData.Section(peripheral) {
+		if alarm, found := strings.CutPrefix(value, "RTC_ALARM_"); found {
+			alarms[alarm] = true
+		}
+	}
+	for _, alarm := range sortedKeys(alarms) {
+		section.defines = append(section.defines, mxDefineType{peripheral + "_ALARM_" + alarm, "1"})
+	}
+	return section
+}
+
+// getDMARequests returns the streams/channels of the DMA controller and the request they serve,
+// e.g. "Dma.USART1_RX.0.Instance=DMA2_Stream2"
+func getDMARequests(iocData *ioc.Ioc, peripheral string) mxSectionType {
+	section := mxSectionType{title: "Requests"}
+	requests := make(map[string]string)
+	for key, instance := range iocData.Section("Dma") {
+		if !strings.HasSuffix(key, ".Instance") {
+			continue
+		}
+		controller, _, found := strings.Cut(instance, "_")
+		if !found {
+			continue
+		}
+		// IP "DMA" covers the controllers DMA1 and DMA2
+		if controller != peripheral && strings.TrimRight(controller, "0123456789") != peripheral {
+			continue
+		}
+		requests[instance] = strings.Split(key, ".")[0]
+	}
+	for _, instance := range sortedKeys(requests) {
+		section.defines = append(section.defines, mxDefineType{instance, requests[instance]})
+	}
+	return section
+}
+
+// hasExtiPins checks for GPIO pins configured as EXTI line in the context
+func hasExtiPins(iocData *ioc.Ioc, context string) bool {
+	return len(getExtiPins(iocData, context)) > 0
+}
+
+// getExtiPins returns the GPIO pins configured as EXTI line, e.g. "PC13.Signal=GPXTI13".
+// These are initialized in MX_GPIO_Init so the values are taken from the .ioc file only.
+func getExtiPins(iocData *ioc.Ioc, context string) map[string]PinDefinition {
+	pinsInfo := make(map[string]PinDefinition)
+	for key, pinData := range iocData.Pins {
+		if !strings.HasPrefix(pinData.Signal, "GPXTI") {
+			continue
+		}
+		if context != "" && pinData.Params["PinAttribute"] != context {
+			continue // pin assigned to another core or security state
+		}
+		if info, ok := getPinFromIoc(key, pinData); ok {
+			pinsInfo[pinData.Signal] = info
+		}
+	}
+	return pinsInfo
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_MXDeviceGolden(t *testing.T) {
+	t.Parallel()
+
+	type contextType struct {
+		context string
+		folder  string
+	}
+	tests := []struct {
+		name     string
+		project  string
+		contexts []contextType
+	}{
+		{"STM32F2", "STM32F2/STM32CubeMX/STM32F217IGHx", []contextType{{"", ""}}},
+		{"STM32F4", "STM32F4/STM32CubeMX/STM32F469NIHx", []contextType{{"", ""}}},
+		{"STM32F7", "STM32F7/STM32CubeMX/STM32F746NGHx", []contextType{{"", ""}}},
+		{"STM32G4", "STM32G4/STM32CubeMX/STM32G474QETx", []contextType{{"", ""}}},
+		{"STM32H5", "STM32H5/STM32CubeMX/STM32H573IIKxQ", []contextType{{"", ""}}},
+		{"STM32H7", "STM32H7/STM32CubeMX/STM32H743XIHx", []contextType{{"", ""}}},
+		{"STM32H7_SC", "STM32H7_SC/STM32CubeMX/device", []contextType{{"", ""}}},
+		{"STM32H7_DC", "STM32H7_DC/STM32CubeMX/STM32H745BGTx", []contextType{{"CortexM4", "CM4"}, {"CortexM7", "CM7"}}},
+		{"STM32U5", "STM32U5/STM32CubeMX/STM32U5G9ZJTxQ", []contextType{{"", ""}}},
+		{"STM32U5_noTZ", "STM32U5_noTZ/STM32CubeMX/Board", []contextType{{"", ""}}},
+		{"STM32U5_TZ", "STM32U5_TZ/STM32CubeMX/Board", []contextType{{"CortexM33NS", "NonSecure"}, {"CortexM33S", "Secure"}}},
+		{"STM32WL_DC", "STM32WL_DC/test/STM32CubeMX/STM32WL54CCUx", []contextType{{"CortexM0Plus", "CM0PLUS"}, {"CortexM4", "CM4"}}},
+	}
+	for _, mode := range []MXDeviceSource{MXDeviceFromIoc, MXDeviceFromSources} {
+		cfg := *DefaultConfig()
+		cfg.mxDevice = mode
+		for _, tt := range tests {
+			project := filepath.Join("../../testdata/testExamples", tt.project)
+			var params []BridgeParamType
+			for _, c := range tt.contexts {
+				params = append(params, BridgeParamType{CubeContext: c.context, CubeContextFolder: c.folder, CgenName: filepath.Join(t.TempDir(), "test.cgen.yml")})
+			}
+			cfgRoot := t.TempDir()
+			if err := readContexts(&cfg, filepath.Join(project, "STM32CubeMX", "STM32CubeMX.ioc"), params, cfgRoot); err != nil {
+				t.Errorf("readContexts() %s (%s) error = %v", tt.name, mode, err)
+				continue
+			}
+			for _, c := range tt.contexts {
+				cfgPath := filepath.Join(project, "MX_Device", c.folder)
+				content, err := os.ReadFile(filepath.Join(cfgRoot, c.folder, "MX_Device.h"))
+				if err != nil {
+					t.Errorf("%s:%s (%s); cannot open MX_Device.h file", tt.name, c.folder, mode)
+					continue
+				}
+				contentRef, err := os.ReadFile(filepath.Join(cfgPath, "MX_Device_ref.h"))
+				if err != nil {
+					t.Errorf("%s:%s (%s); cannot open MX_Device_ref.h file", tt.name, c.folder, mode)
+					continue
+				}
+				// skip header with the generation date
+				lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+				linesRef := strings.Split(strings.ReplaceAll(string(contentRef), "\r\n", "\n"), "\n")
+				if len(lines) < 3 || !reflect.DeepEqual(lines[3:], linesRef[3:]) {
+					t.Errorf("%s:%s (%s); MX_Device.h file content mismatch", tt.name, c.folder, mode)
+				}
+			}
+		}
+	}
+}
+
+func Test_findMXPeripheral(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"USART1":     "USART",
+		"LPUART1":    "LPUART",
+		"FDCAN1":     "FDCAN",
+		"CAN2":       "CAN",
+		"USB_OTG_HS": "USB_OTG",
+		"USB":        "USB",
+		"OCTOSPI2":   "OCTOSPI",
+		"QUADSPI":    "QUADSPI",
+		"SPI2":       "SPI",
+		"I2S3":       "I2S",
+		"TIM12":      "TIM",
+		"ADC345":     "ADC",
+		"DAC1":       "DAC",
+		"RTC":        "RTC",
+		"GPDMA1":     "DMA",
+		"BDMA":       "DMA",
+		"DMA":        "DMA",
+		"EXTI":       "EXTI",
+		"DMA2D":      "",
+		"OCTOSPIM":   "",
+		"HRTIM1":     "",
+		"LPTIM1":     "",
+		"ADF1":       "",
+		"RCC":        "",
+	}
+	for peripheral, want := range tests {
+		got := ""
+		if entry := findMXPeripheral(peripheral); entry != nil {
+			got = entry.family
+		}
+		if got != want {
+			t.Errorf("findMXPeripheral(%v) = %v, want %v", peripheral, got, want)
+		}
+	}
+}
+
+func Test_getPeriphClockFreq(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "RCC.ADC12Freq_Value=150000000\nRCC.ADC345Freq_Value=75000000\nRCC.SAI1Freq_Value=49152000\n"+
+		"RCC.SAIFreq_Value=1000\nRCC.QSPIFreq_Value=200000000\nRCC.OCTOSPIMFreq_Value=160000000\n"+
+		"Mcu.UserConstants=DAC1_PERIPH_CLOCK_FREQ,8000000\n")
+
+	tests := []struct {
+		peripheral string
+		clocks     []string
+		want       string
+	}{
+		{"ADC1", []string{"ADC"}, "150000000"},
+		{"ADC4", []string{"ADC"}, "75000000"},
+		{"SAI1", []string{"SAI"}, "49152000"},
+		{"SAI2", []string{"SAI"}, "1000"},
+		{"QUADSPI", []string{"QSPI"}, "200000000"},
+		{"OCTOSPI1", []string{"OCTOSPI", "OCTOSPIM"}, "160000000"},
+		{"DAC1", []string{"DAC"}, "8000000"},
+		{"RTC", []string{"RTC"}, ""},
+		{"TIM1", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := getPeriphClockFreq(iocData, tt.peripheral, tt.clocks); got != tt.want {
+			t.Errorf("getPeriphClockFreq(%v) = %v, want %v", tt.peripheral, got, tt.want)
+		}
+	}
+}
+
+func Test_getMXSections(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "QUADSPI.FlashSize=24\nQUADSPI.ClockPrescaler=1\n"+
+		"SAI2.Instance-SAI_A_MasterWithClock=SAI$Index_Block_A\nSAI2.Instance-SAI_B_SyncSlave=SAI$Index_Block_B\n"+
+		"SAI2.VirtualMode-SAI_A_MasterWithClock=VM_MASTER\nSAI2.VirtualMode-SAI_B_SyncSlave=VM_SLAVE\n"+
+		"TIM1.Channel-Output\\ Compare1\\ CH1\\ CH1N=TIM_CHANNEL_1\nTIM1.Channel-PWM\\ Generation3\\ CH3=TIM_CHANNEL_3\n"+
+		"ADC3.Channel=ADC_CHANNEL_7\nADC3.Channel-IN7=ADC_CHANNEL_7\nADC3.Channel-IN4=ADC_CHANNEL_4\nADC3.Channel-0\\#ChannelRegularConversion=ADC_CHANNEL_TEMPSENSOR\n"+
+		"DAC1.DAC_Channel-DAC_OUT2=DAC_CHANNEL_2\n"+
+		"RTC.Alarm-Alarm\\ A=RTC_ALARM_A\n"+
+		"Dma.Request0=USART1_RX\nDma.USART1_RX.0.Instance=DMA2_Stream2\nDma.Request1=SPI1_TX\nDma.SPI1_TX.1.Instance=DMA1_Stream4\n"+
+		"Dma.Request2=SDMMC1\nDma.SDMMC1.2.Instance=MDMA_Channel0\n")
+
+	tests := []struct {
+		peripheral string
+		want       []mxSectionType
+	}{
+		{"QUADSPI", []mxSectionType{{"Parameters", []mxDefineType{{"QUADSPI_FLASH_SIZE", "24"}}}}},
+		{"SAI2", []mxSectionType{{"Blocks", []mxDefineType{{"SAI2_BLOCK_A_VM", "VM_MASTER"}, {"SAI2_BLOCK_B_VM", "VM_SLAVE"}}}}},
+		{"TIM1", []mxSectionType{{"Channels", []mxDefineType{{"TIM1_CH1_MODE", "Output Compare"}, {"TIM1_CH3_MODE", "PWM Generation"}}}}},
+		{"ADC3", []mxSectionType{{"Channels", []mxDefineType{{"ADC3_CHANNEL_4", "1"}, {"ADC3_CHANNEL_7", "1"}, {"ADC3_CHANNEL_TEMPSENSOR", "1"}}}}},
+		{"DAC1", []mxSectionType{{"Channels", []mxDefineType{{"DAC1_CHANNEL_2", "1"}}}}},
+		{"RTC", []mxSectionType{{"Alarms", []mxDefineType{{"RTC_ALARM_A", "1"}}}}},
+		{"DMA", []mxSectionType{{"Requests", []mxDefineType{{"DMA1_Stream4", "SPI1_TX"}, {"DMA2_Stream2", "USART1_RX"}}}}},
+		{"DMA2", []mxSectionType{{"Requests", []mxDefineType{{"DMA2_Stream2", "USART1_RX"}}}}},
+		{"MDMA", []mxSectionType{{"Requests", []mxDefineType{{"MDMA_Channel0", "SDMMC1"}}}}},
+		{"GPDMA1", nil},
+		{"USART1", []mxSectionType{{"DMA", []mxDefineType{{"USART1_DMA_RX_INSTANCE", "DMA2_Stream2"}, {"USART1_DMA_RX_CONTROLLER", "DMA2"}, {"USART1_DMA_RX_STREAM", "2"}}}}},
+		{"USART2", nil},
+	}
+	for _, tt := range tests {
+		got := getMXSections(iocData, findMXPeripheral(tt.peripheral), tt.peripheral, "")
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("getMXSections(%v) = %v, want %v", tt.peripheral, got, tt.want)
+		}
+	}
+}
+
+func Test_getExtiPins(t *testing.T) {
+	t.Parallel()
+
+	iocData := parseIoc(t, "PC13.GPIO_ModeDefaultEXTI=GPIO_MODE_IT_FALLING\nPC13.Signal=GPXTI13\nPC13.PinAttribute=CortexM33S\n"+
+		"PA0.Signal=GPXTI0\nPA0.PinAttribute=CortexM33NS\n"+
+		"PB8.Signal=I2C1_SCL\n")
+
+	got := getExtiPins(iocData, "CortexM33S")
+	want := map[string]PinDefinition{
+		"GPXTI13": {p: "PC13", pin: "GPIO_PIN_13", port: "GPIOC", mode: "GPIO_MODE_IT_FALLING"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getExtiPins() = %v, want %v", got, want)
+	}
+	if got := getExtiPins(iocData, ""); len(got) != 2 {
+		t.Errorf("getExtiPins() without context = %v", got)
+	}
+
+	peripherals, err := getPeripherals(parseIoc(t, "Mcu.IP0=TIM2\nMcu.IP1=DMA2D\nMcu.IP2=EXTI\nPC13.Signal=GPXTI13\n"), "")
+	if err != nil || !reflect.DeepEqual(peripherals, []string{"TIM2", "EXTI"}) {
+		t.Errorf("getPeripherals() = %v, %v", peripherals, err)
+	}
+	peripherals, err = getPeripherals(parseIoc(t, "Mcu.IP0=TIM2\n"), "")
+	if err != nil || !reflect.DeepEqual(peripherals, []string{"TIM2"}) {
+		t.Errorf("getPeripherals() = %v, %v", peripherals, err)
+	}
+}
+
+func Test_peripheralSignal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		signal     string
+		peripheral string
+		want       string
+		wantOk     bool
+	}{
+		{"S_TIM1_CH1", "TIM1", "TIM1_CH1", true},
+		{"S_TIM12_CH1", "TIM1", "TIM12_CH1", false},
+		{"USB_OTG_FS_DM", "USB_OTG_FS", "USB_OTG_FS_DM", true},
+		{"USB_DM", "USB", "USB_DM", true},
+		{"I2C1_SCL", "I2C1", "I2C1_SCL", true},
+		{"I2C1_SCL", "I2C2", "I2C1_SCL", false},
+	}
+	for _, tt := range tests {
+		got, ok := peripheralSignal(tt.signal, tt.peripheral)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("peripheralSignal(%v, %v) = %v, %v, want %v, %v", tt.signal, tt.peripheral, got, ok, tt.want, tt.wantOk)
+		}
+	}
+	if !isInstanceLine("  if(htim->Instance==TIM1)", "->Instance==", "TIM1") || isInstanceLine("  if(htim->Instance==TIM12)", "->Instance==", "TIM1") {
+		t.Errorf("isInstanceLine() mismatch for TIM1/TIM12")
+	}
+}
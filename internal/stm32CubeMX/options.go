@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"slices"
+)
+
+// Options of the CubeMX backend given on the command line
+const (
+	OptionMXDevice   = "mx-device"  // source of the MX_Device.h values, see MXDeviceSource
+	OptionLayout     = "layout"     // CubeMX project layout, see ProjectLayout
+	OptionMiddleware = "middleware" // source of the middlewares, see MiddlewareSource
+)
+
+// OptionNames lists the options accepted by SetOptions
+var OptionNames = []string{OptionMXDevice, OptionLayout, OptionMiddleware}
+
+// SetOptions applies the command line options, options not given are reset to the
+// default. All options are checked first, the previous modes are kept on error.
+func (c *CubeMX) SetOptions(options map[string]string) error {
+	for name := range options {
+		if !slices.Contains(OptionNames, name) {
+			return errors.New("unknown option '" + name + "' for generator " + ID)
+		}
+	}
+
+	mxDeviceMode := MXDeviceFromIoc
+	if value, ok := options[OptionMXDevice]; ok {
+		var err error
+		if mxDeviceMode, err = ParseMXDeviceSource(value); err != nil {
+			return err
+		}
+	}
+	layout := LayoutIDE
+	if value, ok := options[OptionLayout]; ok {
+		var err error
+		if layout, err = ParseProjectLayout(value); err != nil {
+			return err
+		}
+	}
+	middlewareMode := MiddlewareFromCubeMX
+	if value, ok := options[OptionMiddleware]; ok {
+		var err error
+		if middlewareMode, err = ParseMiddlewareSource(value); err != nil {
+			return err
+		}
+	}
+	c.setConfig(func(config *Config) {
+		config.mxDevice, config.layout, config.middleware = mxDeviceMode, layout, middlewareMode
+	})
+	return nil
+}
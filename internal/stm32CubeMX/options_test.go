@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"testing"
+)
+
+func Test_SetOptions(t *testing.T) {
+	t.Parallel()
+
+	cubeMX := &CubeMX{}
+	err := cubeMX.SetOptions(map[string]string{OptionMXDevice: "sources", OptionLayout: "cmake", OptionMiddleware: "packs"})
+	if err != nil {
+		t.Fatalf("SetOptions() error = %v", err)
+	}
+	cfg := cubeMX.getConfig()
+	if cfg.mxDevice != MXDeviceFromSources || cfg.layout != LayoutCMake || cfg.middleware != MiddlewareFromPacks {
+		t.Errorf("SetOptions() modes = %v, %v, %v", cfg.mxDevice, cfg.layout, cfg.middleware)
+	}
+
+	for _, options := range []map[string]string{
+		{OptionLayout: "makefile"},
+		{OptionMXDevice: "main.c"},
+		{OptionMiddleware: "vendor"},
+		{"board": "nucleo"},
+	} {
+		if err := cubeMX.SetOptions(options); err == nil {
+			t.Errorf("SetOptions(%v) error = nil", options)
+		}
+	}
+	if layout := cubeMX.getConfig().layout; layout != LayoutCMake {
+		t.Errorf("SetOptions() error changed the layout to %v", layout)
+	}
+
+	if err := cubeMX.SetOptions(map[string]string{OptionLayout: "cmake"}); err != nil {
+		t.Fatalf("SetOptions() error = %v", err)
+	}
+	cfg = cubeMX.getConfig()
+	if cfg.mxDevice != MXDeviceFromIoc || cfg.middleware != MiddlewareFromCubeMX {
+		t.Errorf("SetOptions() did not reset the options not given: %v, %v", cfg.mxDevice, cfg.middleware)
+	}
+	if DefaultConfig().layout != LayoutIDE {
+		t.Errorf("SetOptions() changed the built-in settings")
+	}
+}
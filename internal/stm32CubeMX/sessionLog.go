@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// The session log receives stdout and stderr of CubeMX in the output directory
+const (
+	sessionLogName     = "STM32CubeMX.session.log"
+	sessionLogKeep     = 3       // number of rotated session logs kept
+	sessionLogMaxSize  = 8 << 20 // a session log larger than this is rotated
+	sessionLogMaxFatal = 20      // fatal lines reported per session
+	sessionLogMaxLine  = 4 << 10 // an incomplete line longer than this is scanned and dropped
+)
+
+// fatalPatterns match the CubeMX output lines reported in the *.cgen.log files
+var fatalPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`Exception in thread`),
+	regexp.MustCompile(`^\s*(Caused by: )?[a-z]+(\.[\w$]+)+(Exception|Error)\b`),
+	regexp.MustCompile(`Error: Could not find or load main class|Unable to access jarfile`),
+	regexp.MustCompile(`Could not create the Java Virtual Machine`),
+	regexp.MustCompile(`(?i)licen[cs]e agreement|accept the licen[cs]e`),
+}
+
+// sessionLog writes the CubeMX output to the session log and reports fatal lines
+type sessionLog struct {
+	mutex    sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	line     []byte          // incomplete last line
+	reported map[string]bool // fatal lines already reported
+	report   func(string)
+}
+
+// newSessionLog returns the session log of the output directory. The file is created
+// when CubeMX writes the first output.
+func newSessionLog(workDir string, report func(string)) *sessionLog {
+	return &sessionLog{
+		path:     filepath.Join(workDir, sessionLogName),
+		reported: make(map[string]bool),
+		report:   report,
+	}
+}
+
+// Write never fails, CubeMX must not be stopped by a full disk or a broken log.
+// The file receives all output, only the scan of an endless line is limited.
+func (s *sessionLog) Write(data []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.write(data)
+	s.line = append(s.line, data...)
+	for {
+		i := bytes.IndexByte(s.line, '\n')
+		if i < 0 {
+			break
+		}
+		s.scan(string(s.line[:i]))
+		s.line = s.line[i+1:]
+	}
+	if len(s.line) > sessionLogMaxLine {
+		s.scan(string(s.line))
+		s.line = nil
+	}
+	return len(data), nil
+}
+
+// Close scans the incomplete last line and closes the file
+func (s *sessionLog) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.line) > 0 {
+		s.scan(string(s.line))
+		s.line = nil
+	}
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *sessionLog) write(data []byte) {
+	if s.file != nil && s.size+int64(len(data)) > sessionLogMaxSize {
+		_ = s.file.Close()
+		s.file = nil
+	}
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			log.Debugf("cannot write %v: %v", s.path, err)
+			return
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	if err != nil {
+		log.Debugf("cannot write %v: %v", s.path, err)
+	}
+}
+
+func (s *sessionLog) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return err
+	}
+	if err := utils.RotateFile(s.path, sessionLogKeep); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.size = 0
+	return nil
+}
+
+// scan reports a line matching a fatal pattern once per session
+func (s *sessionLog) scan(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || s.reported[line] || len(s.reported) >= sessionLogMaxFatal {
+		return
+	}
+	for _, pattern := range fatalPatterns {
+		if pattern.MatchString(line) {
+			s.reported[line] = true
+			s.report(line)
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSessionLog(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	var reported []string
+	output := newSessionLog(workDir, func(line string) { reported = append(reported, line) })
+	if _, err := os.Stat(filepath.Join(workDir, sessionLogName)); !os.IsNotExist(err) {
+		t.Errorf("newSessionLog() created the file before any output")
+	}
+
+	lines := []string{
+		"Starting STM32CubeMX\n",
+		"Exception in thread \"main\" java.lang.NullPointer",
+		"Exception: ioc\n\tat com.st.microxplorer.Main.main(Main.java:42)\n",
+		"Exception in thread \"main\" java.lang.NullPointerException: ioc\n",
+		"Error: Could not create the Java Virtual Machine.\n",
+		"Please accept the license agreement",
+	}
+	for _, line := range lines {
+		if n, err := output.Write([]byte(line)); err != nil || n != len(line) {
+			t.Fatalf("Write() = %v, %v", n, err)
+		}
+	}
+	if err := output.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := []string{
+		"Exception in thread \"main\" java.lang.NullPointerException: ioc",
+		"Error: Could not create the Java Virtual Machine.",
+		"Please accept the license agreement",
+	}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("sessionLog reported %q, want %q", reported, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, sessionLogName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text string
+	for _, line := range lines {
+		text += line
+	}
+	if string(data) != text {
+		t.Errorf("session log = %q, want %q", data, text)
+	}
+}
+
+func TestSessionLogLongLine(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	var reported []string
+	output := newSessionLog(workDir, func(line string) { reported = append(reported, line) })
+	line := "Exception in thread \"main\" " + strings.Repeat("x", sessionLogMaxLine)
+	for range 3 {
+		if _, err := output.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		if len(output.line) > sessionLogMaxLine {
+			t.Fatalf("Write() kept %d bytes of an incomplete line", len(output.line))
+		}
+	}
+	if err := output.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reported, []string{line}) {
+		t.Errorf("sessionLog reported %d lines, want the dropped line", len(reported))
+	}
+	data, err := os.ReadFile(filepath.Join(workDir, sessionLogName))
+	if err != nil || string(data) != strings.Repeat(line, 3) {
+		t.Errorf("session log has %d bytes, %v, want %d", len(data), err, 3*len(line))
+	}
+}
+
+func TestSessionLogRotate(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	for i := range sessionLogKeep + 2 {
+		output := newSessionLog(workDir, func(string) {})
+		if _, err := output.Write([]byte{byte('0' + i), '\n'}); err != nil {
+			t.Fatal(err)
+		}
+		if err := output.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(workDir, sessionLogName)
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "4\n" {
+		t.Errorf("session log = %q, %v, want last session", data, err)
+	}
+	data, err = os.ReadFile(path + ".1")
+	if err != nil || string(data) != "3\n" {
+		t.Errorf("session log .1 = %q, %v, want previous session", data, err)
+	}
+	if _, err = os.Stat(path + ".4"); !os.IsNotExist(err) {
+		t.Errorf("session log kept more than %d sessions", sessionLogKeep)
+	}
+}
@@ -14,13 +14,16 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 	"unicode"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
 	"github.com/open-cmsis-pack/generator-bridge/internal/common"
 	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
 	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
@@ -42,6 +45,7 @@ type BridgeParamType struct {
 	CubeContext       string
 	CubeContextFolder string
 	MainLocation      string
+	Middlewares       []string // middleware IPs enabled in the context, e.g. FREERTOS
 }
 
 var watcher *fsnotify.Watcher
@@ -135,7 +139,7 @@ func isRelevantCubeMxEvent(event fsnotify.Event, cubeIocPath, iocprojectPath, mx
 	return false
 }
 
-func processCubeMxUpdate(workDir, iocprojectPath, mxprojectPath string, bridgeParams []BridgeParamType) error {
+func processCubeMxUpdate(cfg *Config, workDir, iocprojectPath, mxprojectPath string, bridgeParams []BridgeParamType) error {
 	if !utils.FileExists(iocprojectPath) {
 		return errors.New("project file not available yet")
 	}
@@ -143,303 +147,415 @@ func processCubeMxUpdate(workDir, iocprojectPath, mxprojectPath string, bridgePa
 		return errors.New(".mxproject file not available yet")
 	}
 
-	mxproject, err := IniReader(mxprojectPath, bridgeParams)
+	mxproject, err := IniReader(cfg, mxprojectPath, bridgeParams)
 	if err != nil {
 		// Log to all cgen files since this is a blocking error
 		for _, bp := range bridgeParams {
-			logCgenError(bp.CgenName, err)
+			cgenlog.Error(bp.CgenName, err)
 		}
 		return err
 	}
-	if err = ReadContexts(iocprojectPath, bridgeParams); err != nil {
+	if err = ReadContexts(cfg, iocprojectPath, bridgeParams); err != nil {
 		// Log to all cgen files since this is a blocking error
 		for _, bp := range bridgeParams {
-			logCgenError(bp.CgenName, err)
+			cgenlog.Error(bp.CgenName, err)
 		}
 		return err
 	}
 
 	log.Debugln("Writing Cgen.yml file")
-	return WriteCgenYml(workDir, mxproject, bridgeParams)
+	return WriteCgenYml(cfg, workDir, mxproject, bridgeParams)
 }
 
-// Process handles setup, launch, and daemon monitoring for CubeMX integration.
-// The live daemon loop in the pid >= 0 branch is intentionally out of scope for
-// unit testing because it depends on live OS processes via procWait, live
-// filesystem watchers, and goroutine synchronization with real-time delays.
-func Process(cbuildGenIdxYmlPath, outPath, cubeMxPath string, runCubeMx bool, pid int) error {
-	var projectFile string
+// ID is the id of the STM32CubeMX generator in global.generator.yml and *.cbuild-gen-idx.yml
+const ID = "CubeMX"
 
-	cRoot := os.Getenv("CMSIS_COMPILER_ROOT")
-	if len(cRoot) == 0 {
-		ex, err := os.Executable()
-		if err != nil {
-			return err
-		}
-		exPath := filepath.Dir(ex)
-		cRoot = filepath.Dir(exPath)
+func init() {
+	generator.Register(ID, func() generator.Generator { return &CubeMX{} })
+}
+
+// CubeMX is the generator backend for STM32CubeMX
+type CubeMX struct {
+	bridgeParams []BridgeParamType
+	config       *Config       // settings from global.generator.yml, see getConfig
+	mutex        sync.Mutex    // serializes the watch loop with the requests of the daemon control channel
+	stop         chan struct{} // ends the watch loop, see Stop
+}
+
+func (c *CubeMX) ID() string {
+	return ID
+}
+
+func (c *CubeMX) ReadBridgeParams(cbuildParams *cbuild.ParamsType) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.bridgeParams = nil
+	return GetBridgeInfo(cbuildParams, &c.bridgeParams)
+}
+
+// WriteLaunchProject returns the existing STM32CubeMX.ioc file or, for a new
+// project, writes the CubeMX script file that creates it.
+func (c *CubeMX) WriteLaunchProject(workDir string) (string, error) {
+	cubeIocPath := filepath.Join(getCubeMxFolder(workDir), "STM32CubeMX.ioc")
+	if utils.FileExists(cubeIocPath) {
+		return cubeIocPath, nil
+	}
+	c.mutex.Lock()
+	cfg := c.getConfig()
+	c.mutex.Unlock()
+	if len(c.bridgeParams) == 0 {
+		return "", errors.New("no cbuild-gen entries found for generator " + ID)
 	}
 
-	// Validate and sanitize cRoot path to prevent path traversal
-	cRoot = filepath.Clean(cRoot)
-	var err error
-	cRoot, err = filepath.Abs(cRoot)
+	projectFile, err := WriteProjectFile(cfg, workDir, c.bridgeParams[0])
 	if err != nil {
-		return fmt.Errorf("invalid CMSIS_COMPILER_ROOT path: %w", err)
+		return "", err
 	}
-	if !utils.DirExists(cRoot) {
-		return fmt.Errorf("CMSIS_COMPILER_ROOT directory does not exist: %s", cRoot)
+	log.Debugf("Generated file: %v", projectFile)
+	return projectFile, nil
+}
+
+func (c *CubeMX) Launch(_, launchFile string) (int, error) {
+	if filepath.Ext(launchFile) == ".ioc" {
+		return Launch(launchFile, "")
 	}
+	return Launch("", launchFile)
+}
 
-	var generatorFile string
-	// #nosec G703 -- cRoot is validated via filepath.Clean, filepath.Abs, and DirExists checks
-	err = filepath.Walk(cRoot, func(path string, f fs.FileInfo, err error) error {
-		if err != nil {
+func (c *CubeMX) WriteCgen(workDir string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cubeIocPath := getCubeMxFolder(workDir)
+	iocprojectPath := filepath.Join(cubeIocPath, "STM32CubeMX.ioc")
+	mxprojectPath := filepath.Join(cubeIocPath, ".mxproject")
+	for _, file := range []string{iocprojectPath, mxprojectPath} {
+		if !utils.FileExists(file) {
+			err := errors.New("file not found: " + file + ", generate the code with CubeMX first")
+			for _, bp := range c.bridgeParams {
+				cgenlog.Error(bp.CgenName, err)
+			}
 			return err
 		}
-		if f == nil {
-			return errors.New("unexpected nil FileInfo for path: " + path)
-		}
-		if f.Mode().IsRegular() && strings.Contains(path, "global.generator.yml") {
-			generatorFile = path
-			return nil
-		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-	if len(generatorFile) == 0 {
-		return errors.New("config file 'global.generator.yml' not found")
 	}
+	return processCubeMxUpdate(c.getConfig(), workDir, iocprojectPath, mxprojectPath, c.bridgeParams)
+}
 
-	var gParms generator.ParamsType
-	err = ReadGeneratorYmlFile(generatorFile, &gParms)
+// Watch regenerates the cgen files while the CubeMX process with the pid runs
+func (c *CubeMX) Watch(workDir string, pid int) error {
+	proc, err := os.FindProcess(pid) // this only works for windows as it is now
 	if err != nil {
-		return err
+		return nil
 	}
-
-	var parms cbuild.ParamsType
-	err = ReadCbuildGenIdxYmlFile(cbuildGenIdxYmlPath, "CubeMX", &parms)
-	if err != nil {
-		return err
+	// cubeMX already runs
+	if runtime.GOOS != "windows" {
+		err = proc.Signal(syscall.Signal(0))
+		if err != nil {
+			return nil // CubeMX does not run anymore
+		}
 	}
 
-	var bridgeParams []BridgeParamType
-	err = GetBridgeInfo(&parms, &bridgeParams)
+	c.deleteCgenLogs()
+	running = true
+	go procWait(proc)
+
+	return c.watch(workDir)
+}
+
+// Command returns the command starting CubeMX with the .ioc or CubeMX script file.
+// The output of CubeMX goes to the session log in the output directory.
+func (c *CubeMX) Command(workDir, launchFile string) (*exec.Cmd, error) {
+	var cmd *exec.Cmd
+	var err error
+	if filepath.Ext(launchFile) == ".ioc" {
+		cmd, err = NewCommand(launchFile, "")
+	} else {
+		cmd, err = NewCommand("", launchFile)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
+	output := newSessionLog(workDir, c.reportFatal)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	return cmd, nil
+}
 
-	workDir := filepath.Dir(cbuildGenIdxYmlPath)
-	if parms.Output != "" {
-		if filepath.IsAbs(parms.Output) {
-			workDir = parms.Output
-		} else {
-			workDir = filepath.Join(workDir, parms.Output)
+// Supervise waits for the started CubeMX, reports its exit status in the *.cgen.log
+// files and regenerates the files while it runs
+func (c *CubeMX) Supervise(workDir string, cmd *exec.Cmd) error {
+	c.deleteCgenLogs()
+	running = true
+	go func() {
+		err := cmd.Wait()
+		if output, ok := cmd.Stdout.(*sessionLog); ok {
+			_ = output.Close()
 		}
-	} else {
-		if filepath.IsAbs(outPath) {
-			workDir = outPath
-		} else {
-			workDir = filepath.Join(workDir, outPath)
+		c.reportExit(cmd.ProcessState, err)
+		c.Stop()
+	}()
+
+	return c.watch(workDir)
+}
+
+// deleteCgenLogs deletes the *.cgen.log files of the previous run at daemon startup
+func (c *CubeMX) deleteCgenLogs() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cgenlog.DeleteAll(cgenPathsFromBridgeParams(c.bridgeParams))
+}
+
+// reportExit records how CubeMX ended in the *.cgen.log files
+func (c *CubeMX) reportExit(state *os.ProcessState, err error) {
+	c.mutex.Lock()
+	cgenPaths := cgenPathsFromBridgeParams(c.bridgeParams)
+	c.mutex.Unlock()
+
+	var status error
+	switch {
+	case state == nil:
+		status = fmt.Errorf("cannot wait for CubeMX to end: %w", err)
+	case state.Sys() != nil && state.Sys().(syscall.WaitStatus).Signaled():
+		status = fmt.Errorf("CubeMX terminated by signal %v", state.Sys().(syscall.WaitStatus).Signal())
+	case state.ExitCode() != 0:
+		status = fmt.Errorf("CubeMX exited with code %d", state.ExitCode())
+	}
+	if status == nil {
+		log.Debugln("CubeMX ended")
+		for _, cgenPath := range cgenPaths {
+			cgenlog.InfoIfExists(cgenPath, "CubeMX exited with code 0")
 		}
+		return
+	}
+	log.Warnln(status)
+	for _, cgenPath := range cgenPaths {
+		cgenlog.Error(cgenPath, status)
+	}
+}
+
+// reportFatal records a fatal line of the CubeMX output in the *.cgen.log files
+func (c *CubeMX) reportFatal(line string) {
+	c.mutex.Lock()
+	cgenPaths := cgenPathsFromBridgeParams(c.bridgeParams)
+	c.mutex.Unlock()
+
+	err := errors.New("CubeMX: " + line)
+	log.Warnln(err)
+	for _, cgenPath := range cgenPaths {
+		cgenlog.Error(cgenPath, err)
 	}
-	workDir = filepath.Clean(workDir)
-	workDir = filepath.ToSlash(workDir)
+}
+
+// watch regenerates the files on changes of the CubeMX output until Stop is called
+func (c *CubeMX) watch(workDir string) error {
+	cubeIocPath := getCubeMxFolder(workDir)
+	iocprojectPath := filepath.Join(cubeIocPath, "STM32CubeMX.ioc")
+	mxprojectPath := filepath.Join(cubeIocPath, ".mxproject")
+
+	c.mutex.Lock()
+	cgenPaths := cgenPathsFromBridgeParams(c.bridgeParams)
+	c.mutex.Unlock()
 
-	err = os.MkdirAll(workDir, os.ModePerm)
+	var err error
+	watcher, err = fsnotify.NewWatcher()
 	if err != nil {
 		return err
 	}
-
-	cubeIocPath := workDir
-	if pid >= 0 {
-		lastPath := filepath.Base(cubeIocPath)
-		if lastPath != "STM32CubeMX" {
-			cubeIocPath = filepath.Join(cubeIocPath, "STM32CubeMX")
+	defer func() {
+		if watcher != nil {
+			_ = watcher.Close()
+			watcher = nil
 		}
-		iocprojectPath := filepath.Join(cubeIocPath, "STM32CubeMX.ioc")
-		mxprojectPath := filepath.Join(cubeIocPath, ".mxproject")
+	}()
 
-		cgenPaths := cgenPathsFromBridgeParams(bridgeParams)
+	if utils.DirExists(workDir) {
+		if err = watcher.Add(workDir); err != nil {
+			log.Debugf("failed to watch work dir '%s': %v", workDir, err)
+		}
+	}
+	if utils.DirExists(cubeIocPath) {
+		if err = watcher.Add(cubeIocPath); err != nil {
+			log.Debugf("failed to watch CubeMX dir '%s': %v", cubeIocPath, err)
+		}
+	}
 
-		proc, err := os.FindProcess(pid) // this only works for windows as it is now
-		if err == nil {                  // cubeMX already runs
-			if runtime.GOOS != "windows" {
-				err = proc.Signal(syscall.Signal(0))
-				if err != nil {
-					return nil // CubeMX does not run anymore
-				}
-			}
+	if err = c.update(workDir, iocprojectPath, mxprojectPath); err != nil {
+		log.Debugf("initial CubeMX generation attempt skipped: %v", err)
+	}
 
-			running = true
-			go procWait(proc)
+	stop := c.stopChannel()
+	const debounceInterval = time.Second
+	debounceTimer := time.NewTimer(debounceInterval)
+	debounceTimer.Stop() // start inactive
+	hasRelevantEvent := false
 
-			watcher, err = fsnotify.NewWatcher()
-			if err != nil {
-				return err
+	for running {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				running = false
+				break
 			}
-			defer func() {
-				if watcher != nil {
-					_ = watcher.Close()
-					watcher = nil
-				}
-			}()
 
-			if utils.DirExists(workDir) {
-				if err = watcher.Add(workDir); err != nil {
-					log.Debugf("failed to watch work dir '%s': %v", workDir, err)
-				}
+			if !handleCubeMxWatchEvent(event, cubeIocPath, iocprojectPath, mxprojectPath, cgenPaths, watcher.Add) {
+				continue
 			}
-			if utils.DirExists(cubeIocPath) {
-				if err = watcher.Add(cubeIocPath); err != nil {
-					log.Debugf("failed to watch CubeMX dir '%s': %v", cubeIocPath, err)
+
+			hasRelevantEvent = true
+			resetDebounceTimer(debounceTimer, debounceInterval)
+
+		case <-debounceTimer.C:
+			if hasRelevantEvent {
+				if err = c.update(workDir, iocprojectPath, mxprojectPath); err != nil {
+					log.Debugf("CubeMX generation attempt skipped: %v", err)
 				}
+				hasRelevantEvent = false
 			}
 
-			// Delete old cgen.log files at daemon startup
-			deleteAllCgenLogs(bridgeParams)
+		case <-stop:
+			running = false
 
-			if err = processCubeMxUpdate(workDir, iocprojectPath, mxprojectPath, bridgeParams); err != nil {
-				log.Debugf("initial CubeMX generation attempt skipped: %v", err)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				running = false
+				break
 			}
+			log.Debugf("watcher error: %v", err)
+		}
+	}
+	debounceTimer.Stop()
 
-			const debounceInterval = time.Second
-			debounceTimer := time.NewTimer(debounceInterval)
-			debounceTimer.Stop() // start inactive
-			hasRelevantEvent := false
-
-			for running {
-				select {
-				case event, ok := <-watcher.Events:
-					if !ok {
-						running = false
-						break
-					}
+	return nil
+}
 
-					if !handleCubeMxWatchEvent(event, cubeIocPath, iocprojectPath, mxprojectPath, cgenPaths, watcher.Add) {
-						continue
-					}
+// update regenerates the files with the bridge parameters of the last reload
+func (c *CubeMX) update(workDir, iocprojectPath, mxprojectPath string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return processCubeMxUpdate(c.getConfig(), workDir, iocprojectPath, mxprojectPath, c.bridgeParams)
+}
 
-					hasRelevantEvent = true
-					resetDebounceTimer(debounceTimer, debounceInterval)
+// Stop ends the watch loop, CubeMX keeps running
+func (c *CubeMX) Stop() {
+	select {
+	case c.stopChannel() <- struct{}{}:
+	default: // already requested
+	}
+}
 
-				case <-debounceTimer.C:
-					if hasRelevantEvent {
-						if err = processCubeMxUpdate(workDir, iocprojectPath, mxprojectPath, bridgeParams); err != nil {
-							log.Debugf("CubeMX generation attempt skipped: %v", err)
-						}
-						hasRelevantEvent = false
-					}
+func (c *CubeMX) stopChannel() chan struct{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.stop == nil {
+		c.stop = make(chan struct{}, 1)
+	}
+	return c.stop
+}
 
-				case err, ok := <-watcher.Errors:
-					if !ok {
-						running = false
-						break
-					}
-					log.Debugf("watcher error: %v", err)
-				}
-			}
-			debounceTimer.Stop()
-		}
+// getCubeMxFolder returns the STM32CubeMX project folder below the work dir
+func getCubeMxFolder(workDir string) string {
+	if filepath.Base(workDir) != "STM32CubeMX" {
+		return filepath.Join(workDir, "STM32CubeMX")
 	}
+	return workDir
+}
 
-	if runCubeMx {
-		lastPath := filepath.Base(cubeIocPath)
-		if lastPath != "STM32CubeMX" {
-			cubeIocPath = filepath.Join(cubeIocPath, "STM32CubeMX")
-		}
-		cubeIocPath = filepath.Join(cubeIocPath, "STM32CubeMX.ioc")
+func Launch(iocFile, projectFile string) (int, error) {
+	cmd, err := NewCommand(iocFile, projectFile)
+	if err != nil {
+		return -1, err
+	}
+	log.Debugf("Start CubeMX as %v", cmd)
+	if err := cmd.Start(); err != nil {
+		log.Fatal(err)
+		return -1, err
+	}
 
-		var err error
-		var pid int
-		if utils.FileExists(cubeIocPath) {
-			pid, err = Launch(cubeIocPath, "")
-			if err != nil {
-				return fmt.Errorf("failed to launch generator '%s': %w. If not installed, get it from '%s'", gParms.ID, err, gParms.DownloadURL)
-			}
-		} else {
-			projectFile, err = WriteProjectFile(workDir, bridgeParams[0])
-			if err != nil {
-				return err
-			}
-			log.Debugf("Generated file: %v", projectFile)
+	return cmd.Process.Pid, nil
+}
 
-			pid, err = Launch("", projectFile)
-			if err != nil {
-				return fmt.Errorf("failed to launch generator '%s': %w. If not installed, get it from '%s'", gParms.ID, err, gParms.DownloadURL)
-			}
-		}
-		// here cubeMX runs
-		exe, err := os.Executable()
+// NewCommand returns the command starting CubeMX with the .ioc file or, for a new
+// project, with the CubeMX script file
+func NewCommand(iocFile, projectFile string) (*exec.Cmd, error) {
+	cubeEnv, err := getCubeMxPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate and sanitize file paths to prevent command injection
+	if iocFile != "" {
+		iocFile, err = validateLaunchFile("iocFile", iocFile)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		ownPath, err := filepath.EvalSymlinks((exe))
+		log.Infoln("Launching STM32CubeMX with ", iocFile)
+		return cubeMxCommand(cubeEnv, iocFile), nil
+	}
+	if projectFile != "" {
+		projectFile, err = validateLaunchFile("projectFile", projectFile)
 		if err != nil {
-			return err
-		}
-		cmd := exec.Command(ownPath) //nolint
-		cmd.Args = os.Args
-		cmd.Args = append(cmd.Args, "-p", fmt.Sprint(pid)) // pid of cubeMX
-		log.Debugf("cmd.Start as %v", cmd)
-		if err := cmd.Start(); err != nil { // start myself as a daemon
-			log.Fatal(err)
-			return err
+			return nil, err
 		}
+		log.Infoln("Launching STM32CubeMX with -s ", projectFile)
+		return cubeMxCommand(cubeEnv, "-s", projectFile), nil
 	}
-	return nil
+	log.Infoln("Launching STM32CubeMX...")
+	return cubeMxCommand(cubeEnv), nil
 }
 
-func Launch(iocFile, projectFile string) (int, error) {
+// NewScriptCommand returns the command running the CubeMX script file without the user interface
+func NewScriptCommand(scriptFile string) (*exec.Cmd, error) {
+	cubeEnv, err := getCubeMxPath()
+	if err != nil {
+		return nil, err
+	}
+	scriptFile, err = validateLaunchFile("scriptFile", scriptFile)
+	if err != nil {
+		return nil, err
+	}
+	log.Infoln("Running STM32CubeMX with -q ", scriptFile)
+	return cubeMxCommand(cubeEnv, "-q", scriptFile), nil
+}
+
+// getCubeMxPath returns the validated CubeMX installation directory
+func getCubeMxPath() (string, error) {
 	const cubeEnvVar = "STM32CubeMX_PATH"
 	cubeEnv := os.Getenv(cubeEnvVar)
 	if cubeEnv == "" {
-		return -1, errors.New("environment variable for CubeMX not set: " + cubeEnvVar)
+		return "", errors.New("environment variable for CubeMX not set: " + cubeEnvVar)
 	}
 
 	// Validate and sanitize cubeEnv path to prevent command injection
 	cubeEnv = filepath.Clean(cubeEnv)
-	var err error
-	cubeEnv, err = filepath.Abs(cubeEnv)
+	cubeEnv, err := filepath.Abs(cubeEnv)
 	if err != nil {
-		return -1, fmt.Errorf("invalid STM32CubeMX_PATH: %w", err)
+		return "", fmt.Errorf("invalid STM32CubeMX_PATH: %w", err)
 	}
 	if !utils.DirExists(cubeEnv) {
-		return -1, fmt.Errorf("STM32CubeMX_PATH directory does not exist: %s", cubeEnv)
+		return "", fmt.Errorf("STM32CubeMX_PATH directory does not exist: %s", cubeEnv)
 	}
+	return cubeEnv, nil
+}
 
-	// Validate and sanitize file paths to prevent command injection
-	if iocFile != "" {
-		iocFile = filepath.Clean(iocFile)
-		iocFile, err = filepath.Abs(iocFile)
-		if err != nil {
-			return -1, fmt.Errorf("invalid iocFile path: %w", err)
-		}
-		if !utils.FileExists(iocFile) {
-			return -1, fmt.Errorf("iocFile does not exist: %s", iocFile)
-		}
-		log.Infoln("Launching STM32CubeMX with ", iocFile)
-	} else if projectFile != "" {
-		projectFile = filepath.Clean(projectFile)
-		projectFile, err = filepath.Abs(projectFile)
-		if err != nil {
-			return -1, fmt.Errorf("invalid projectFile path: %w", err)
-		}
-		if !utils.FileExists(projectFile) {
-			return -1, fmt.Errorf("projectFile does not exist: %s", projectFile)
-		}
-		log.Infoln("Launching STM32CubeMX with -s ", projectFile)
-	} else {
-		log.Infoln("Launching STM32CubeMX...")
+// validateLaunchFile returns the absolute path of an existing file passed to CubeMX
+func validateLaunchFile(kind, file string) (string, error) {
+	file = filepath.Clean(file)
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s path: %w", kind, err)
+	}
+	if !utils.FileExists(file) {
+		return "", fmt.Errorf("%s does not exist: %s", kind, file)
 	}
+	return file, nil
+}
 
+// cubeMxCommand returns the command running CubeMX of the installation directory with the arguments
+func cubeMxCommand(cubeEnv string, args ...string) *exec.Cmd {
 	var pathJava string
-	var arg0 string
-	var arg1 string
 	var pathCubeMx string
-	var cmd *exec.Cmd
+	var javaArgs []string
 
 	switch runtime.GOOS {
 	case "windows":
@@ -448,9 +564,7 @@ func Launch(iocFile, projectFile string) (int, error) {
 
 	case "darwin":
 		pathJava = filepath.Join(cubeEnv, "jre", "Contents", "Home", "bin", "java")
-		arg0 = filepath.Join(cubeEnv, "stm32cubemx.icns")
-		arg0 = "-Xdock:icon=" + arg0
-		arg1 = "-Xdock:name=STM32CubeMX"
+		javaArgs = append(javaArgs, "-Xdock:icon="+filepath.Join(cubeEnv, "stm32cubemx.icns"), "-Xdock:name=STM32CubeMX")
 		pathCubeMx = filepath.Join(cubeEnv, "STM32CubeMX")
 
 	default:
@@ -458,43 +572,37 @@ func Launch(iocFile, projectFile string) (int, error) {
 		pathCubeMx = filepath.Join(cubeEnv, "STM32CubeMX")
 	}
 
-	if runtime.GOOS == "darwin" {
-		if iocFile != "" {
-			// #nosec G702 -- iocFile is validated via filepath.Clean, filepath.Abs, and FileExists checks
-			cmd = exec.Command(pathJava, arg0, arg1, "-jar", pathCubeMx, iocFile)
-		} else if projectFile != "" {
-			// #nosec G702 -- projectFile is validated via filepath.Clean, filepath.Abs, and FileExists checks
-			cmd = exec.Command(pathJava, arg0, arg1, "-jar", pathCubeMx, "-s", projectFile)
-		} else {
-			// #nosec G702 -- pathJava and pathCubeMx are constructed from validated cubeEnv path
-			cmd = exec.Command(pathJava, arg0, arg1, "-jar", pathCubeMx)
-		}
-	} else {
-		if iocFile != "" {
-			// #nosec G702 -- iocFile is validated via filepath.Clean, filepath.Abs, and FileExists checks
-			cmd = exec.Command(pathJava, "-jar", pathCubeMx, iocFile)
-		} else if projectFile != "" {
-			// #nosec G702 -- projectFile is validated via filepath.Clean, filepath.Abs, and FileExists checks
-			cmd = exec.Command(pathJava, "-jar", pathCubeMx, "-s", projectFile)
-		} else {
-			// #nosec G702 -- pathJava and pathCubeMx are constructed from validated cubeEnv path
-			cmd = exec.Command(pathJava, "-jar", pathCubeMx)
-		}
-	}
-	log.Debugf("Start CubeMX as %v", cmd)
-	if err := cmd.Start(); err != nil {
-		log.Fatal(err)
-		return -1, err
-	}
-
-	return cmd.Process.Pid, nil
+	javaArgs = append(javaArgs, "-jar", pathCubeMx)
+	// #nosec G702 -- pathJava and pathCubeMx are constructed from the validated cubeEnv path, the file arguments are validated via filepath.Clean, filepath.Abs, and FileExists checks
+	return exec.Command(pathJava, append(javaArgs, args...)...)
 }
 
-func WriteProjectFile(workDir string, params BridgeParamType) (string, error) {
-	filePath := filepath.Join(workDir, "project.script")
-	log.Debugf("Writing CubeMX project file %v", filePath)
+func WriteProjectFile(cfg *Config, workDir string, params BridgeParamType) (string, error) {
+	var text utils.TextBuilder
+	if err := addProjectLines(cfg, &text, workDir, params); err != nil {
+		return "", err
+	}
+	return writeScript(filepath.Join(workDir, "project.script"), text.GetLine())
+}
 
+// WriteGenerateScript writes the CubeMX script generating the code without user
+// interaction. The STM32CubeMX.ioc file is loaded, for a new project it is created
+// as by WriteProjectFile.
+func WriteGenerateScript(cfg *Config, workDir string, params BridgeParamType) (string, error) {
 	var text utils.TextBuilder
+	cubeIocPath := filepath.Join(getCubeMxFolder(workDir), "STM32CubeMX.ioc")
+	if utils.FileExists(cubeIocPath) {
+		text.AddLine("config load", utils.AddQuotes(cubeScriptPath(cubeIocPath)))
+	} else if err := addProjectLines(cfg, &text, workDir, params); err != nil {
+		return "", err
+	}
+	text.AddLine("project generate")
+	text.AddLine("exit")
+	return writeScript(filepath.Join(workDir, "generate.script"), text.GetLine())
+}
+
+// addProjectLines adds the CubeMX script commands creating the project for the device or board
+func addProjectLines(cfg *Config, text *utils.TextBuilder, workDir string, params BridgeParamType) error {
 	if params.BoardName != "" && params.BoardVendor == "STMicroelectronics" {
 		text.AddLine("loadboard", params.BoardName, "allmodes")
 	} else {
@@ -512,24 +620,31 @@ func WriteProjectFile(workDir string, params BridgeParamType) (string, error) {
 	}
 	text.AddLine("project name", "STM32CubeMX")
 
-	toolchain, err := GetToolchain(params.Compiler)
+	toolchain, err := GetToolchain(cfg, params.Compiler)
 	if err != nil {
-		return "", err
+		return err
 	}
 	text.AddLine("project toolchain", utils.AddQuotes(toolchain))
+	text.AddLine("project path", utils.AddQuotes(cubeScriptPath(workDir)))
+	text.AddLine("SetCopyLibrary", utils.AddQuotes("copy only"))
+	return nil
+}
 
-	cubeWorkDir := workDir
+// cubeScriptPath returns a path in the notation of the host, as CubeMX expects it in scripts
+func cubeScriptPath(path string) string {
 	if runtime.GOOS == "windows" {
-		cubeWorkDir = filepath.FromSlash(cubeWorkDir)
+		return filepath.FromSlash(path)
 	}
-	text.AddLine("project path", utils.AddQuotes(cubeWorkDir))
-	text.AddLine("SetCopyLibrary", utils.AddQuotes("copy only"))
+	return path
+}
 
+func writeScript(filePath, text string) (string, error) {
+	log.Debugf("Writing CubeMX project file %v", filePath)
 	if utils.FileExists(filePath) {
 		os.Remove(filePath)
 	}
 
-	err = os.WriteFile(filePath, []byte(text.GetLine()), 0600)
+	err := os.WriteFile(filePath, []byte(text), 0600)
 	if err != nil {
 		log.Errorf("Error writing %v", err)
 		return "", err
@@ -548,12 +663,6 @@ func ReadCbuildGenIdxYmlFile(path, generatorID string, parms *cbuild.ParamsType)
 	return nil
 }
 
-func ReadGeneratorYmlFile(path string, parms *generator.ParamsType) error {
-	log.Debugf("Reading generator.yml file: '%v'", path)
-	err := generator.Read(path, parms)
-	return err
-}
-
 func GetBridgeInfo(parms *cbuild.ParamsType, bridgeParams *[]BridgeParamType) error {
 	var boardName string
 	var boardVendor string
@@ -653,6 +762,8 @@ var filterFiles = map[string]string{
 	"/STM32CubeMX/Drivers/CMSIS/Include": "CMSIS include folder (delivered by ARM::CMSIS)",
 }
 
+// FilterFile applies the built-in filters to files and include paths,
+// true if the entry is not added to the cgen.yml file
 func FilterFile(file string) bool {
 	for key, value := range filterFiles {
 		if strings.Contains(file, key) {
@@ -664,6 +775,85 @@ func FilterFile(file string) bool {
 	return false
 }
 
+// libraryCompilers maps markers in the path of a precompiled library to the compilers it is built for
+var libraryCompilers = map[string][]string{
+	"iar":      {"IAR"},
+	"ewarm":    {"IAR"},
+	"keil":     {"AC6"},
+	"mdk":      {"AC6"},
+	"armcc":    {"AC6"},
+	"armclang": {"AC6"},
+	"ac6":      {"AC6"},
+	"gcc":      {"GCC", "CLANG"},
+	"cubeide":  {"GCC", "CLANG"},
+	"atollic":  {"GCC", "CLANG"},
+	"sw4stm32": {"GCC", "CLANG"},
+	"clang":    {"CLANG"},
+	"llvm":     {"CLANG"},
+}
+
+// GetLibraryCompilers classifies a file of PreviousLibFiles, e.g.
+// "Middlewares/ST/ARM/DSP/Lib/libarm_cortexM4lf_math.a" is a GCC and CLANG library.
+// It returns nil if the file is not a precompiled library and an empty list if the
+// library has no marker of the compilers it is built for.
+func GetLibraryCompilers(file string) []string {
+	compilers := []string{}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".lib":
+		compilers = []string{"AC6"}
+	case ".a":
+		// IAR libraries are .a files as well, only the GNU naming "lib<name>.a" marks GCC
+		if strings.HasPrefix(strings.ToLower(filepath.Base(file)), "lib") {
+			compilers = []string{"GCC", "CLANG"}
+		}
+	default:
+		return nil
+	}
+
+	// markers in folder or file names, e.g. "iar_cortexM4lf_math.a" or "Lib/GCC/libSTemWin.a"
+	tokens := strings.FieldsFunc(strings.ToLower(filepath.ToSlash(strings.TrimSuffix(file, filepath.Ext(file)))), func(r rune) bool {
+		return r == '/' || r == '_' || r == '.'
+	})
+	for _, token := range tokens {
+		if marked, ok := libraryCompilers[token]; ok {
+			compilers = marked
+		}
+	}
+	return compilers
+}
+
+// getHeaderFolder returns the include path of a header file in the "./" notation of ConvertFilename
+func getHeaderFolder(file string) string {
+	folder := filepath.ToSlash(filepath.Dir(file))
+	if strings.HasPrefix(file, "./") && folder != "." && !strings.HasPrefix(folder, "../") {
+		folder = "./" + folder
+	}
+	return folder
+}
+
+// fileCategories maps file extensions to the csolution file categories
+var fileCategories = map[string]string{
+	".c":   "sourceC",
+	".cpp": "sourceCpp",
+	".cc":  "sourceCpp",
+	".cxx": "sourceCpp",
+	".s":   "sourceAsm",
+	".asm": "sourceAsm",
+	".h":   "header",
+	".hpp": "header",
+	".ld":  "linkerScript",
+	".sct": "linkerScript",
+	".icf": "linkerScript",
+	".a":   "library",
+	".lib": "library",
+	".o":   "object",
+}
+
+// GetFileCategory returns the csolution category of a file from its extension, "" if unknown
+func GetFileCategory(file string) string {
+	return fileCategories[strings.ToLower(filepath.Ext(file))]
+}
+
 func FilterDefine(define string) bool {
 	if len(define) == 0 {
 		return true
@@ -683,6 +873,12 @@ func FilterDefine(define string) bool {
 	return false
 }
 
+// ParseDefine splits a .mxproject define "NAME" or "NAME=VALUE" into name and value,
+// false if the name is not a valid C identifier
+func ParseDefine(define string) (cbuild.DefineElement, bool) {
+	return filterPolicyType{}.parseDefine(define)
+}
+
 func FindMxProject(context string, mxprojectAll MxprojectAllType) (MxprojectType, error) {
 	if len(mxprojectAll.Mxproject) == 0 {
 		return MxprojectType{}, errors.New("no .mxproject read")
@@ -700,13 +896,13 @@ func FindMxProject(context string, mxprojectAll MxprojectAllType) (MxprojectType
 	return MxprojectType{}, nil
 }
 
-func WriteCgenYml(outPath string, mxprojectAll MxprojectAllType, bridgeParams []BridgeParamType) error {
+func WriteCgenYml(cfg *Config, outPath string, mxprojectAll MxprojectAllType, bridgeParams []BridgeParamType) error {
 	for _, parm := range bridgeParams {
 		mxproject, err := FindMxProject(parm.CubeContext, mxprojectAll)
 		if err != nil {
 			continue
 		}
-		err = WriteCgenYmlSub(outPath, mxproject, parm)
+		err = WriteCgenYmlSub(cfg, outPath, mxproject, parm)
 		if err != nil {
 			return err
 		}
@@ -715,28 +911,44 @@ func WriteCgenYml(outPath string, mxprojectAll MxprojectAllType, bridgeParams []
 	return nil
 }
 
-func WriteCgenYmlSub(outPath string, mxproject MxprojectType, bridgeParam BridgeParamType) error {
+func WriteCgenYmlSub(cfg *Config, outPath string, mxproject MxprojectType, bridgeParam BridgeParamType) error {
 	var cgen cbuild.CgenType
 
-	relativePathAdd, err := GetRelativePathAdd(outPath, bridgeParam.Compiler)
+	relativePathAdd, err := GetRelativePathAdd(cfg, outPath, bridgeParam.Compiler)
 	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
+		cgenlog.Error(bridgeParam.CgenName, err)
 		return err
 	}
 
 	cgen.GeneratorImport.ForBoard = bridgeParam.BoardName
 	cgen.GeneratorImport.ForDevice = bridgeParam.Device
 
+	filters := cfg.getFilterPolicy(bridgeParam.ProjectName)
 	for _, define := range mxproject.PreviousUsedFiles.CDefines {
-		if FilterDefine(define) {
+		element, ok := filters.parseDefine(define)
+		if !ok {
+			continue
+		}
+		cgen.GeneratorImport.Define = append(cgen.GeneratorImport.Define, element)
+	}
+
+	for _, name := range bridgeParam.Middlewares {
+		middleware := findMiddleware(name)
+		if cfg.middleware != MiddlewareFromPacks {
+			log.Debugf("%v: using sources copied by CubeMX, components %v of packs %v are the CMSIS alternative", middleware.name, middleware.components, middleware.packs)
 			continue
 		}
-		cgen.GeneratorImport.Define = append(cgen.GeneratorImport.Define, define)
+		for _, pack := range middleware.packs {
+			if !slices.ContainsFunc(cgen.GeneratorImport.Packs, func(p cbuild.CgenPacksType) bool { return p.Pack == pack }) {
+				cgen.GeneratorImport.Packs = append(cgen.GeneratorImport.Packs, cbuild.CgenPacksType{Pack: pack})
+			}
+		}
+		log.Infof("%v: sources copied by CubeMX not added to %v, add components %v to the cproject", middleware.name, bridgeParam.CgenName, middleware.components)
 	}
 
 	for _, headerPath := range mxproject.PreviousUsedFiles.HeaderPath {
 		headerPath, _ = utils.ConvertFilename(outPath, headerPath, relativePathAdd)
-		if FilterFile(headerPath) {
+		if filters.filterAddPath(headerPath) || cfg.isPackMiddlewareFile(headerPath, bridgeParam.Middlewares) {
 			continue
 		}
 		cgen.GeneratorImport.AddPath = append(cgen.GeneratorImport.AddPath, headerPath)
@@ -749,70 +961,109 @@ func WriteCgenYmlSub(outPath string, mxproject MxprojectType, bridgeParam Bridge
 	cfgPath, _ = utils.ConvertFilename(outPath, cfgPath, "")
 	cgen.GeneratorImport.AddPath = append(cgen.GeneratorImport.AddPath, cfgPath)
 
-	var groupSrc cbuild.CgenGroupsType
-	var groupHalDriver cbuild.CgenGroupsType
 	var groupTz cbuild.CgenGroupsType
 
-	groupSrc.Group = "CubeMX"
-	groupHalDriver.Group = "STM32 HAL Driver"
-	groupHalFilter := "HAL_Driver"
+	groupPolicy := cfg.getGroupPolicy(bridgeParam.ProjectName)
+	groups := groupPolicy.newGroups()
 
 	for _, file := range mxproject.PreviousUsedFiles.SourceFiles {
-		if FilterFile(file) {
+		if filters.filterFile(file) || cfg.isPackMiddlewareFile(file, bridgeParam.Middlewares) {
 			continue
 		}
 		file, _ = utils.ConvertFilename(outPath, file, relativePathAdd)
-		if strings.Contains(file, groupHalFilter) {
-			var cgenFile cbuild.CgenFilesType
-			cgenFile.File = file
-			groupHalDriver.Files = append(groupHalDriver.Files, cgenFile)
-		} else {
-			var cgenFile cbuild.CgenFilesType
-			cgenFile.File = file
-			groupSrc.Files = append(groupSrc.Files, cgenFile)
+		groups = groupPolicy.addFile(cfg, groups, file, bridgeParam)
+	}
+
+	// PreviousGenFiles lists the files generated from the .ioc, headers are added for IDE visibility
+	genFiles := slices.Concat(mxproject.PreviousGenFiles.SourceFilesList, mxproject.PreviousGenFiles.HeaderFilesList)
+	for _, file := range genFiles {
+		if filters.filterFile(file) {
+			continue
+		}
+		file = GetGenFilePath(outPath, file)
+		if !utils.FileExists(file) {
+			continue
+		}
+		file, err = utils.ConvertFilenameRel(outPath, file)
+		if err != nil {
+			continue
+		}
+		if !containsGroupFile(groups, file) {
+			groups = groupPolicy.addFile(cfg, groups, file, bridgeParam)
+		}
+	}
+	for _, headerPath := range mxproject.PreviousGenFiles.HeaderPathList {
+		headerPath = GetGenFilePath(outPath, headerPath)
+		if !utils.DirExists(headerPath) {
+			continue
+		}
+		headerPath, err = utils.ConvertFilenameRel(outPath, headerPath)
+		if err != nil || filters.filterAddPath(headerPath) {
+			continue
+		}
+		if !slices.Contains(cgen.GeneratorImport.AddPath, headerPath) {
+			cgen.GeneratorImport.AddPath = append(cgen.GeneratorImport.AddPath, headerPath)
 		}
 	}
 
-	var cgenFile cbuild.CgenFilesType
-	startupFile, err := GetStartupFile(outPath, bridgeParam)
-	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
-		return err
+	startupFile := GetGenStartupFile(outPath, mxproject, bridgeParam)
+	if startupFile == "" {
+		startupFile, err = GetStartupFile(cfg, outPath, bridgeParam)
+		if err != nil {
+			cgenlog.Error(bridgeParam.CgenName, err)
+			return err
+		}
 	}
 	startupFile, err = utils.ConvertFilenameRel(outPath, startupFile)
 	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
+		cgenlog.Error(bridgeParam.CgenName, err)
 		return err
 	}
-	cgenFile.File = startupFile
-	groupSrc.Files = append(groupSrc.Files, cgenFile)
+	groups = groupPolicy.addFile(cfg, groups, startupFile, bridgeParam)
 
-	systemFile, err := GetSystemFile(outPath, bridgeParam)
-	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
-		return err
+	systemFile := GetGenSystemFile(outPath, mxproject)
+	if systemFile == "" {
+		systemFile, err = GetSystemFile(cfg, outPath, bridgeParam)
+		if err != nil {
+			cgenlog.Error(bridgeParam.CgenName, err)
+			return err
+		}
 	}
 	systemFile, err = utils.ConvertFilenameRel(outPath, systemFile)
 	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
+		cgenlog.Error(bridgeParam.CgenName, err)
 		return err
 	}
-	cgenFile.File = systemFile
-	groupSrc.Files = append(groupSrc.Files, cgenFile)
-
-	// linkerFiles, err := GetLinkerScripts(outPath, bridgeParam)
-	// if err != nil {
-	// 	return err
-	// }
-	// for _, file := range linkerFiles {
-	// 	file, err = utils.ConvertFilenameRel(outPath, file)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// 	var cgenFile cbuild.CgenFilesType
-	// 	cgenFile.File = file
-	// 	groupSrc.Files = append(groupSrc.Files, cgenFile)
-	// }
+	groups = groupPolicy.addFile(cfg, groups, systemFile, bridgeParam)
+
+	linkerScript, err := GetLinkerScript(cfg, outPath, bridgeParam)
+	if err != nil {
+		log.Warn("no linker script added to " + bridgeParam.CgenName + ": " + err.Error())
+	} else {
+		linkerScript, err = utils.ConvertFilenameRel(outPath, linkerScript)
+		if err != nil {
+			cgenlog.Error(bridgeParam.CgenName, err)
+			return err
+		}
+		cgen.GeneratorImport.Linker = append(cgen.GeneratorImport.Linker, cbuild.CgenLinkerType{
+			Script:      linkerScript,
+			ForCompiler: bridgeParam.Compiler,
+		})
+	}
+
+	var groupLibraries cbuild.CgenGroupsType
+	groupLibraries.Group = "Libraries"
+	for _, file := range mxproject.PreviousLibFiles.LibFiles {
+		compilers := GetLibraryCompilers(file)
+		if compilers == nil || (len(compilers) > 0 && !slices.Contains(compilers, bridgeParam.Compiler)) {
+			continue
+		}
+		file, _ = utils.ConvertFilename(outPath, file, relativePathAdd)
+		var cgenFile cbuild.CgenFilesType
+		cgenFile.File = file
+		cgenFile.Category = "library"
+		groupLibraries.Files = append(groupLibraries.Files, cgenFile)
+	}
 
 	var groupsThirdParty []cbuild.CgenGroupsType
 	for _, files := range mxproject.ThirdPartyIqFiles {
@@ -822,19 +1073,37 @@ func WriteCgenYmlSub(outPath string, mxproject MxprojectType, bridgeParam Bridge
 			file, _ = utils.ConvertFilename(outPath, file, relativePathAdd)
 			var cgenFile cbuild.CgenFilesType
 			cgenFile.File = file
+			cgenFile.Category = GetFileCategory(file)
 			groupThirdParty.Files = append(groupThirdParty.Files, cgenFile)
 		}
 		for _, file := range files.SourceAsmFiles {
 			file, _ = utils.ConvertFilename(outPath, file, relativePathAdd)
 			var cgenFile cbuild.CgenFilesType
 			cgenFile.File = file
+			cgenFile.Category = "sourceAsm"
 			groupThirdParty.Files = append(groupThirdParty.Files, cgenFile)
 		}
+		for _, file := range files.IncludeFiles {
+			file, _ = utils.ConvertFilename(outPath, file, relativePathAdd)
+			includePath := file
+			if GetFileCategory(file) == "header" { // else include folder, which may contain a dot
+				var cgenFile cbuild.CgenFilesType
+				cgenFile.File = file
+				cgenFile.Category = "header"
+				groupThirdParty.Files = append(groupThirdParty.Files, cgenFile)
+				includePath = getHeaderFolder(file)
+			}
+			if !slices.Contains(cgen.GeneratorImport.AddPath, includePath) {
+				cgen.GeneratorImport.AddPath = append(cgen.GeneratorImport.AddPath, includePath)
+			}
+		}
 		groupsThirdParty = append(groupsThirdParty, groupThirdParty)
 	}
 
-	cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, groupSrc)
-	cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, groupHalDriver)
+	cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, groups...)
+	if len(groupLibraries.Files) > 0 {
+		cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, groupLibraries)
+	}
 	for _, group := range groupsThirdParty {
 		if len(group.Files) > 0 {
 			cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, group)
@@ -851,42 +1120,30 @@ func WriteCgenYmlSub(outPath string, mxproject MxprojectType, bridgeParam Bridge
 		cgen.GeneratorImport.Groups = append(cgen.GeneratorImport.Groups, groupTz)
 	}
 
+	groupPolicy.applyOptions(cgen.GeneratorImport.Groups)
+
 	err = common.WriteYml(bridgeParam.CgenName, &cgen)
 	if err != nil {
-		logCgenError(bridgeParam.CgenName, err)
+		cgenlog.Error(bridgeParam.CgenName, err)
 		return err
 	}
-	logCgenInfoIfLogExists(bridgeParam.CgenName, "cgen.yml generated successfully")
+	cgenlog.InfoIfExists(bridgeParam.CgenName, "cgen.yml generated successfully")
 
 	return nil
 }
 
-func GetToolchain(compiler string) (string, error) {
-	var toolchainMapping = map[string]string{
-		"AC6":   "MDK-ARM V5",
-		"GCC":   "STM32CubeIDE",
-		"IAR":   "EWARM",
-		"CLANG": "STM32CubeIDE",
-	}
-
-	toolchain, ok := toolchainMapping[compiler]
-	if !ok {
-		return "", errors.New("unknown compiler '" + compiler + "'")
+func GetToolchain(cfg *Config, compiler string) (string, error) {
+	toolchain, err := cfg.getToolchainType(compiler)
+	if err != nil {
+		return "", err
 	}
-	return toolchain, nil
+	return toolchain.toolchain, nil
 }
 
-func GetRelativePathAdd(outPath string, compiler string) (string, error) {
-	var pathMapping = map[string]string{
-		"AC6":   "MDK-ARM",
-		"GCC":   "",
-		"IAR":   "EWARM",
-		"CLANG": "",
-	}
-
-	folder, ok := pathMapping[compiler]
-	if !ok {
-		return "", errors.New("unknown compiler '" + compiler + "'")
+func GetRelativePathAdd(cfg *Config, outPath string, compiler string) (string, error) {
+	toolchain, err := cfg.getToolchainType(compiler)
+	if err != nil {
+		return "", err
 	}
 
 	lastPath := filepath.Base(outPath)
@@ -894,60 +1151,83 @@ func GetRelativePathAdd(outPath string, compiler string) (string, error) {
 	if lastPath != "STM32CubeMX" {
 		relativePathAdd = filepath.Join(relativePathAdd, "STM32CubeMX")
 	}
-	relativePathAdd = filepath.Join(relativePathAdd, folder)
+	relativePathAdd = filepath.Join(relativePathAdd, toolchain.pathAdd)
 
 	return relativePathAdd, nil
 }
 
-func GetToolchainFolderPath(outPath string, compiler string) (string, error) {
-	var toolchainFolderMapping = map[string]string{
-		"AC6":   "MDK-ARM",
-		"GCC":   "STM32CubeIDE",
-		"IAR":   "EWARM",
-		"CLANG": "STM32CubeIDE",
+func GetToolchainFolderPath(cfg *Config, outPath string, compiler string) (string, error) {
+	toolchain, err := cfg.getToolchainType(compiler)
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(GetProjectFolderPath(outPath), toolchain.folder), nil
+}
 
-	toolchainFolder, ok := toolchainFolderMapping[compiler]
-	if !ok {
-		return "", errors.New("unknown compiler '" + compiler + "'")
+func GetStartupFile(cfg *Config, outPath string, bridgeParams BridgeParamType) (string, error) {
+	toolchain, err := cfg.getToolchainType(bridgeParams.Compiler)
+	if err != nil {
+		return "", err
 	}
-
-	lastPath := filepath.Base(outPath)
-	toolchainFolderPath := outPath
-	if lastPath != "STM32CubeMX" {
-		toolchainFolderPath = filepath.Join(outPath, "STM32CubeMX")
+	toolchainFolder, err := GetToolchainFolderPath(cfg, outPath, bridgeParams.Compiler)
+	if err != nil {
+		return "", err
 	}
-	toolchainFolderPath = filepath.Join(toolchainFolderPath, toolchainFolder)
-
-	return toolchainFolderPath, nil
-}
 
-func GetStartupFile(outPath string, bridgeParams BridgeParamType) (string, error) {
-	var startupFolder string
-	var fileFilter string
-	var fileExtensions = []string{".s", ".S", ".c"}
+	startupFolder := getStartupFolder(toolchainFolder, toolchain, bridgeParams)
+	if !utils.DirExists(startupFolder) {
+		errorString := "Directory not found: " + startupFolder
+		log.Error(errorString)
+		return "", errors.New(errorString)
+	}
 
-	startupFolder, err := GetToolchainFolderPath(outPath, bridgeParams.Compiler)
+	startupFile, err := findStartupFile(startupFolder, bridgeParams.CubeContextFolder)
 	if err != nil {
 		return "", err
 	}
-
-	if bridgeParams.CubeContextFolder != "" {
-		fileFilter = "_" + bridgeParams.CubeContextFolder
+	if startupFile == "" {
+		errorString := "startup file not found"
+		log.Error(errorString)
+		return "", errors.New(errorString)
 	}
+	return startupFile, nil
+}
 
-	if bridgeParams.Compiler == "GCC" || bridgeParams.Compiler == "CLANG" {
-		startupSubFolder := "Application"
-		if bridgeParams.MainLocation != "Src" {
-			startupSubFolder = filepath.Join(startupSubFolder, "User")
+// GetGenStartupFile returns the startup file of the advanced folder structure, "" if not found.
+// CubeMX places it in the Startup folder next to the generated sources, e.g. Core/Startup.
+func GetGenStartupFile(outPath string, mxproject MxprojectType, bridgeParams BridgeParamType) string {
+	if !isAdvancedFolderStructure(mxproject) {
+		return ""
+	}
+	for _, folder := range GetGenSourceFolders(outPath, mxproject) {
+		startupFolder := filepath.Join(filepath.Dir(folder), "Startup")
+		if !utils.DirExists(startupFolder) {
+			continue
+		}
+		if startupFile, err := findStartupFile(startupFolder, bridgeParams.CubeContextFolder); err == nil && startupFile != "" {
+			return startupFile
 		}
-		startupFolder = filepath.Join(startupFolder, bridgeParams.CubeContextFolder, startupSubFolder, "Startup")
 	}
+	return ""
+}
+
+// isAdvancedFolderStructure reports the Core/Src layout of the generated files
+func isAdvancedFolderStructure(mxproject MxprojectType) bool {
+	switch strings.ToLower(mxproject.PreviousGenFiles.AdvancedFolderStructure) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
 
-	if !utils.DirExists(startupFolder) {
-		errorString := "Directory not found: " + startupFolder
-		log.Error(errorString)
-		return "", errors.New(errorString)
+// findStartupFile returns the startup file in the folder, "" if there is none. Out of
+// several startup files the one of the context is taken, else the one without suffix.
+func findStartupFile(startupFolder, contextFolder string) (string, error) {
+	var fileFilter string
+	var fileExtensions = []string{".s", ".S", ".c"}
+
+	if contextFolder != "" {
+		fileFilter = "_" + contextFolder
 	}
 
 	var startupFile string
@@ -959,13 +1239,16 @@ func GetStartupFile(outPath string, bridgeParams BridgeParamType) (string, error
 		fExt[element] = true
 	}
 
-	err = filepath.Walk(startupFolder, func(path string, f fs.FileInfo, err error) error {
+	err := filepath.Walk(startupFolder, func(path string, f fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if f == nil {
 			return errors.New("unexpected nil FileInfo for path: " + path)
 		}
+		if f.IsDir() && path != startupFolder {
+			return filepath.SkipDir // e.g. Core and Drivers next to the startup file of a CMake project
+		}
 		if f.Mode().IsRegular() &&
 			strings.HasPrefix(f.Name(), "startup_") &&
 			fExt[filepath.Ext(f.Name())] {
@@ -997,40 +1280,27 @@ func GetStartupFile(outPath string, bridgeParams BridgeParamType) (string, error
 		}
 	}
 
-	if startupFile == "" {
-		errorString := "startup file not found"
-		log.Error(errorString)
-		return "", errors.New(errorString)
-	}
-
 	return startupFile, err
 }
 
-func GetSystemFile(outPath string, bridgeParams BridgeParamType) (string, error) {
-	var toolchainFolder string
+func GetSystemFile(cfg *Config, outPath string, bridgeParams BridgeParamType) (string, error) {
 	var systemFolder string
 
-	toolchainFolder, err := GetToolchainFolderPath(outPath, bridgeParams.Compiler)
+	_, err := cfg.getToolchainType(bridgeParams.Compiler)
 	if err != nil {
 		return "", err
 	}
+	projectFolder := GetProjectFolderPath(outPath)
 
 	if bridgeParams.ProjectType == "multi-core" {
-		systemFolder = filepath.Dir(toolchainFolder)
-		systemFolder = filepath.Join(systemFolder, "Common")
-		if !utils.DirExists(toolchainFolder) {
+		systemFolder = filepath.Join(projectFolder, "Common")
+		if !utils.DirExists(systemFolder) {
 			systemFolder = ""
 		}
 	}
 
 	if systemFolder == "" {
-		systemFolder = filepath.Dir(toolchainFolder)
-
-		if bridgeParams.CubeContextFolder != "" {
-			systemFolder = filepath.Join(systemFolder, bridgeParams.CubeContextFolder)
-		}
-
-		systemFolder = filepath.Join(systemFolder, bridgeParams.MainLocation)
+		systemFolder = filepath.Join(projectFolder, bridgeParams.CubeContextFolder, bridgeParams.MainLocation)
 	}
 
 	if !utils.DirExists(systemFolder) {
@@ -1064,82 +1334,152 @@ func GetSystemFile(outPath string, bridgeParams BridgeParamType) (string, error)
 	return systemFile, err
 }
 
-// func GetLinkerScripts(outPath string, bridgeParams BridgeParamType) ([]string, error) {
-// 	var linkerFolder string
-// 	var fileExtesion string
-// 	var fileFilter string
-
-// 	linkerFolder, err := GetToolchainFolderPath(outPath, bridgeParams.Compiler)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	switch bridgeParams.Compiler {
-// 	case "AC6":
-// 		fileExtesion = ".sct"
-// 	case "IAR":
-// 		fileExtesion = ".icf"
-// 	case "GCC", "CLANG":
-// 		fileExtesion = ".ld"
-// 	default:
-// 		return nil, errors.New("unknown compiler '" + bridgeParams.Compiler + "'")
-// 	}
-
-// 	if bridgeParams.GeneratorMap != "" {
-// 		linkerFolder = filepath.Join(linkerFolder, bridgeParams.GeneratorMap)
-// 	}
-
-// 	switch bridgeParams.Compiler {
-// 	case "AC6", "IAR":
-// 		switch bridgeParams.ProjectType {
-// 		case "single-core":
-// 			fileFilter = ""
-// 		case "multi-core":
-// 			fileFilter = "_" + bridgeParams.ForProjectPart
-// 		case "trustzone":
-// 			if bridgeParams.ForProjectPart == "secure" {
-// 				fileFilter = "_s."
-// 			}
-// 			if bridgeParams.ForProjectPart == "non-secure" {
-// 				fileFilter = "_ns."
-// 			}
-// 		}
-
-// 	case "GCC", "CLANG":
-// 		switch bridgeParams.ProjectType {
-// 		case "multi-core":
-// 			linkerFolder = filepath.Join(linkerFolder, bridgeParams.ForProjectPart)
-// 		case "trustzone":
-// 			if bridgeParams.ForProjectPart == "secure" {
-// 				linkerFolder = filepath.Join(linkerFolder, "Secure")
-// 			}
-// 			if bridgeParams.ForProjectPart == "non-secure" {
-// 				linkerFolder = filepath.Join(linkerFolder, "NonSecure")
-// 			}
-// 		}
-// 	default:
-// 		return nil, errors.New("unknown compiler '" + bridgeParams.Compiler + "'")
-// 	}
-
-// 	if !utils.DirExists(linkerFolder) {
-// 		errorString := "Directory not found: " + linkerFolder
-// 		log.Error(errorString)
-// 		return nil, errors.New(errorString)
-// 	}
-
-// 	var linkerScripts []string
-// 	err = filepath.Walk(linkerFolder, func(path string, f fs.FileInfo, err error) error {
-// 		if f.Mode().IsRegular() && strings.HasSuffix(f.Name(), fileExtesion) {
-// 			if fileFilter != "" {
-// 				if strings.Contains(f.Name(), fileFilter) {
-// 					linkerScripts = append(linkerScripts, path)
-// 				}
-// 			} else {
-// 				linkerScripts = append(linkerScripts, path)
-// 			}
-// 		}
-// 		return nil
-// 	})
-
-// 	return linkerScripts, err
-// }
+// GetGenFilePath resolves a file or folder of PreviousGenFiles, e.g. "..\Core\Src\main.c".
+// The entries are relative to the toolchain folder and always point into the project folder.
+func GetGenFilePath(outPath, file string) string {
+	file = strings.ReplaceAll(file, "\\", "/")
+	for strings.HasPrefix(file, "../") || strings.HasPrefix(file, "./") {
+		file = strings.TrimPrefix(strings.TrimPrefix(file, "./"), "../")
+	}
+	return filepath.Join(GetProjectFolderPath(outPath), filepath.FromSlash(file))
+}
+
+// GetGenSourceFolders returns the existing folders of the generated source files,
+// e.g. Core/Src for the advanced folder structure or Src otherwise
+func GetGenSourceFolders(outPath string, mxproject MxprojectType) []string {
+	var folders []string
+	for _, folder := range mxproject.PreviousGenFiles.SourcePathList {
+		folders = append(folders, GetGenFilePath(outPath, folder))
+	}
+	for _, file := range mxproject.PreviousGenFiles.SourceFilesList {
+		folders = append(folders, filepath.Dir(GetGenFilePath(outPath, file)))
+	}
+
+	var sourceFolders []string
+	for _, folder := range folders {
+		if utils.DirExists(folder) && !slices.Contains(sourceFolders, folder) {
+			sourceFolders = append(sourceFolders, folder)
+		}
+	}
+	return sourceFolders
+}
+
+// GetGenSystemFile returns the system file next to the generated sources, "" if not found
+func GetGenSystemFile(outPath string, mxproject MxprojectType) string {
+	for _, folder := range GetGenSourceFolders(outPath, mxproject) {
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() &&
+				strings.HasPrefix(entry.Name(), "system_stm32") &&
+				strings.HasSuffix(entry.Name(), ".c") {
+				return filepath.Join(folder, entry.Name())
+			}
+		}
+	}
+	return ""
+}
+
+func GetLinkerScripts(cfg *Config, outPath string, bridgeParams BridgeParamType) ([]string, error) {
+	var linkerFolder string
+	var fileExtension string
+	var fileFilter string
+
+	toolchain, err := cfg.getToolchainType(bridgeParams.Compiler)
+	if err != nil {
+		return nil, err
+	}
+	linkerFolder, err = GetToolchainFolderPath(cfg, outPath, bridgeParams.Compiler)
+	if err != nil {
+		return nil, err
+	}
+
+	switch bridgeParams.Compiler {
+	case "AC6":
+		fileExtension = ".sct"
+	case "IAR":
+		fileExtension = ".icf"
+	case "GCC", "CLANG":
+		fileExtension = ".ld"
+	default:
+		return nil, errors.New("unknown compiler '" + bridgeParams.Compiler + "'")
+	}
+
+	if bridgeParams.GeneratorMap != "" {
+		linkerFolder = filepath.Join(linkerFolder, bridgeParams.GeneratorMap)
+	}
+
+	switch bridgeParams.Compiler {
+	case "AC6", "IAR":
+		switch bridgeParams.ProjectType {
+		case "multi-core":
+			fileFilter = "_" + strings.ToLower(bridgeParams.CubeContextFolder) + "." // e.g. "_cm7." for stm32h745xg_flash_CM7.sct
+		case "trustzone":
+			if bridgeParams.ForProjectPart == "secure" {
+				fileFilter = "_s."
+			}
+			if bridgeParams.ForProjectPart == "non-secure" {
+				fileFilter = "_ns."
+			}
+		}
+
+	}
+	if toolchain.perContext && bridgeParams.GeneratorMap == "" {
+		switch bridgeParams.ProjectType {
+		case "multi-core", "trustzone":
+			linkerFolder = filepath.Join(linkerFolder, bridgeParams.CubeContextFolder)
+		}
+	}
+
+	if !utils.DirExists(linkerFolder) {
+		errorString := "Directory not found: " + linkerFolder
+		return nil, errors.New(errorString)
+	}
+
+	var linkerScripts []string
+	err = filepath.Walk(linkerFolder, func(path string, f fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if f == nil {
+			return errors.New("unexpected nil FileInfo for path: " + path)
+		}
+		if f.IsDir() && path != linkerFolder {
+			return filepath.SkipDir // scripts of other cores or security states
+		}
+		if f.Mode().IsRegular() && strings.HasSuffix(f.Name(), fileExtension) &&
+			strings.Contains(strings.ToLower(f.Name()), fileFilter) {
+			linkerScripts = append(linkerScripts, path)
+		}
+		return nil
+	})
+
+	return linkerScripts, err
+}
+
+// GetLinkerScript selects the flash variant out of the generated linker scripts,
+// e.g. "stm32h745xg_flash_CM7.sct" rather than "stm32h745xx_sram1_CM7.sct" or "stm32h745xx_flash_rw_sram1_CM7.icf"
+func GetLinkerScript(cfg *Config, outPath string, bridgeParams BridgeParamType) (string, error) {
+	linkerScripts, err := GetLinkerScripts(cfg, outPath, bridgeParams)
+	if err != nil {
+		return "", err
+	}
+	if len(linkerScripts) == 0 {
+		return "", errors.New("linker script not found")
+	}
+
+	linkerScript := linkerScripts[0]
+	var flashScript string
+	for _, file := range linkerScripts {
+		name := strings.ToLower(filepath.Base(file))
+		if strings.Contains(name, "flash") && (flashScript == "" || len(name) < len(filepath.Base(flashScript))) {
+			flashScript = file
+		}
+	}
+	if flashScript != "" {
+		linkerScript = flashScript
+	}
+	return linkerScript, nil
+}
@@ -8,14 +8,19 @@ package stm32cubemx
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/open-cmsis-pack/generator-bridge/internal/cbuild"
+	"github.com/open-cmsis-pack/generator-bridge/internal/cgenlog"
+	"gopkg.in/yaml.v3"
 )
 
 // Test_WriteProjectFile verifies generation of project.script for both board and device cases
@@ -115,7 +120,7 @@ func Test_WriteProjectFile(t *testing.T) {
 			if err := os.MkdirAll(tt.args.workDir, 0o755); err != nil {
 				t.Fatalf("failed to create temp workdir: %v", err)
 			}
-			gotFile, err := WriteProjectFile(tt.args.workDir, tt.args.params)
+			gotFile, err := WriteProjectFile(DefaultConfig(), tt.args.workDir, tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("WriteProjectFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -148,7 +153,7 @@ func Test_WriteProjectFile(t *testing.T) {
 
 			// Second run: calling again must overwrite the file (no duplicate lines expected)
 			if !tt.wantErr {
-				_, err2 := WriteProjectFile(tt.args.workDir, tt.args.params)
+				_, err2 := WriteProjectFile(DefaultConfig(), tt.args.workDir, tt.args.params)
 				if err2 != nil {
 					t.Fatalf("second WriteProjectFile() call failed: %v", err2)
 				}
@@ -290,6 +295,22 @@ func Test_cgenPathsFromBridgeParams(t *testing.T) {
 	}
 }
 
+func Test_WriteCgenMissingInput(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cgenName := filepath.Join(workDir, "test.cgen.yml")
+	cubeMX := &CubeMX{bridgeParams: []BridgeParamType{{CgenName: cgenName}}}
+
+	err := cubeMX.WriteCgen(workDir)
+	if err == nil || !strings.Contains(err.Error(), "STM32CubeMX.ioc") || !strings.Contains(err.Error(), "generate the code with CubeMX first") {
+		t.Fatalf("WriteCgen() error = %v, want missing STM32CubeMX.ioc", err)
+	}
+	if _, err := os.Stat(cgenName); err == nil {
+		t.Errorf("WriteCgen() wrote %v without CubeMX output", cgenName)
+	}
+}
+
 func Test_handleCubeMxWatchEvent(t *testing.T) {
 	t.Parallel()
 
@@ -400,54 +421,6 @@ func Test_resetDebounceTimer(t *testing.T) {
 	})
 }
 
-func Test_ProcessEarlyFailures(t *testing.T) {
-	t.Run("missing_cmsis_compiler_root_dir", func(t *testing.T) {
-		t.Setenv("CMSIS_COMPILER_ROOT", filepath.Join(t.TempDir(), "missing"))
-
-		err := Process(filepath.Join(t.TempDir(), "unused.cbuild-gen-idx.yml"), "out", "", false, -1)
-		if err == nil {
-			t.Fatal("Process() error = nil, want missing CMSIS_COMPILER_ROOT directory error")
-		}
-		if !strings.Contains(err.Error(), "CMSIS_COMPILER_ROOT directory does not exist") {
-			t.Fatalf("Process() error = %q, want missing CMSIS_COMPILER_ROOT directory", err)
-		}
-	})
-
-	t.Run("missing_global_generator_file", func(t *testing.T) {
-		root := t.TempDir()
-		t.Setenv("CMSIS_COMPILER_ROOT", root)
-
-		err := Process(filepath.Join(root, "unused.cbuild-gen-idx.yml"), "out", "", false, -1)
-		if err == nil {
-			t.Fatal("Process() error = nil, want missing global.generator.yml error")
-		}
-		if !strings.Contains(err.Error(), "config file 'global.generator.yml' not found") {
-			t.Fatalf("Process() error = %q, want missing global.generator.yml", err)
-		}
-	})
-
-	t.Run("missing_cbuild_idx_after_generator_load", func(t *testing.T) {
-		root := t.TempDir()
-		t.Setenv("CMSIS_COMPILER_ROOT", root)
-
-		generatorPath := filepath.Join(root, "global.generator.yml")
-		generatorYML := "generator:\n  - id: CubeMX\n    description: test\n    download-url: https://example.invalid\n    run: ../bin/cbridge\n    path: $SolutionDir()$/STM32CubeMX/$TargetType$\n"
-		if err := os.WriteFile(generatorPath, []byte(generatorYML), 0600); err != nil {
-			t.Fatalf("os.WriteFile() error = %v", err)
-		}
-
-		missingIdx := filepath.Join(root, "missing.cbuild-gen-idx.yml")
-		err := Process(missingIdx, "out", "", false, -1)
-		if err == nil {
-			t.Fatal("Process() error = nil, want missing cbuild idx error")
-		}
-		errMsg := strings.ToLower(err.Error())
-		if !strings.Contains(errMsg, "file not found") && !strings.Contains(errMsg, "cannot find the file specified") && !strings.Contains(errMsg, "no such file or directory") {
-			t.Fatalf("Process() error = %q, want missing cbuild idx error", err)
-		}
-	})
-}
-
 func Test_GetBridgeInfo(t *testing.T) {
 
 	// Single core Device
@@ -749,6 +722,52 @@ func Test_FilterDefine(t *testing.T) {
 	}
 }
 
+func Test_GetLibraryCompilers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"Middlewares/ST/ARM/DSP/Lib/libarm_cortexM4lf_math.a": {"GCC", "CLANG"},
+		"Middlewares/ST/ARM/DSP/Lib/iar_cortexM4lf_math.a":    {"IAR"},
+		"Middlewares/ST/ARM/DSP/Lib/arm_cortexM4lf_math.lib":  {"AC6"},
+		"Middlewares/ST/STemWin/Lib/STemWin_CM4_wc32.a":       {},
+		"Middlewares/ST/STemWin/Lib/EWARM/STemWin_CM7.a":      {"IAR"},
+		"Middlewares/ST/AI/Lib/Keil/NetworkRuntime_CM7.LIB":   {"AC6"},
+		"Middlewares/ST/AI/Lib/GCC/libNetworkRuntime_CM7.a":   {"GCC", "CLANG"},
+		"Middlewares/ST/AI/Inc/ai_platform.h":                 nil,
+		"Drivers/STM32F4xx_HAL_Driver/Src/stm32f4xx_hal.c":    nil,
+	}
+	for file, want := range tests {
+		if got := GetLibraryCompilers(file); !reflect.DeepEqual(got, want) {
+			t.Errorf("GetLibraryCompilers(%v) = %v, want %v", file, got, want)
+		}
+	}
+}
+
+func Test_ParseDefine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		define string
+		want   map[string]string
+		ok     bool
+	}{
+		{"USE_HAL_DRIVER", map[string]string{"USE_HAL_DRIVER": ""}, true},
+		{"HSE_VALUE=8000000", map[string]string{"HSE_VALUE": "8000000"}, true},
+		{"DEBUG = 1", map[string]string{"DEBUG": "1"}, true},
+		{"VERSION=\\\"1.0\\\"", map[string]string{"VERSION": "\\\"1.0\\\""}, true},
+		{"EMPTY=", map[string]string{"EMPTY": ""}, true},
+		{"1BAD=1", nil, false},
+		{"=1", nil, false},
+		{"A-B=1", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDefine(tt.define)
+		if ok != tt.ok || !reflect.DeepEqual(got.NameValue, tt.want) {
+			t.Errorf("ParseDefine(%q) = %v, %v, want %v, %v", tt.define, got.NameValue, ok, tt.want, tt.ok)
+		}
+	}
+}
+
 // Test_FindMxProject covers selection logic for mxproject contexts.
 func Test_FindMxProject(t *testing.T) {
 	t.Parallel()
@@ -832,6 +851,9 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 		filepath.Join(base, "Templates"),
 		filepath.Join(base, "Drivers", "CMSIS", "Include"),
 		filepath.Join(base, "tp", "src"),
+		filepath.Join(base, "tp", "inc"),
+		filepath.Join(base, "tp", "cfg"),
+		filepath.Join(base, "tp", "lib"),
 		filepath.Join(base, "MX_Device"),
 	}
 	for _, d := range dirs {
@@ -849,6 +871,9 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 	if err := os.WriteFile(systemFile, []byte("system"), 0o600); err != nil {
 		t.Fatalf("write system: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(base, "STM32CubeIDE", "STM32F429ZITX_FLASH.ld"), []byte("linker"), 0o600); err != nil {
+		t.Fatalf("write linker: %v", err)
+	}
 
 	// Source files referenced
 	if err := os.WriteFile(filepath.Join(base, "Src", "main.c"), []byte("int main(){}"), 0o600); err != nil {
@@ -866,15 +891,24 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(base, "tp", "src", "startup.s"), []byte(""), 0o600); err != nil {
 		t.Fatalf("tp startup: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(base, "tp", "lib", "libtp_math.a"), []byte(""), 0o600); err != nil {
+		t.Fatalf("tp library: %v", err)
+	}
+	for _, header := range []string{"lib.h", "lib_conf.h"} {
+		if err := os.WriteFile(filepath.Join(base, "tp", "inc", header), []byte(""), 0o600); err != nil {
+			t.Fatalf("tp header: %v", err)
+		}
+	}
 
 	// Minimal mxproject with defines, header path, sources and third party entries
 	mx := MxprojectType{}
-	mx.PreviousUsedFiles.CDefines = []string{"VALID_DEFINE", "1BAD", "_GOOD"}                   // 1BAD filtered
-	mx.PreviousUsedFiles.HeaderPath = []string{"include", "Templates", "Drivers/CMSIS/Include"} // some filtered
+	mx.PreviousUsedFiles.CDefines = []string{"VALID_DEFINE", "1BAD", "_GOOD", "HSE_VALUE=8000000", "2BAD=1"} // 1BAD, 2BAD filtered
+	mx.PreviousUsedFiles.HeaderPath = []string{"include", "Templates", "Drivers/CMSIS/Include"}              // some filtered
 	mx.PreviousUsedFiles.SourceFiles = []string{"Src/main.c", "Src/HAL_Driver/stm32_hal.c", "system_ignore.c"}
+	mx.PreviousLibFiles.LibFiles = []string{"tp/inc/lib.h", "tp/lib/libtp_math.a", "tp/lib/iar_tp_math.a", "tp/lib/tp_math.lib", "tp/lib/tp_any.a"}
 	mx.ThirdPartyIqFiles = []ThirdPartyIqNames{{
 		ThirdPartyIqName: "IPLIB",
-		IncludeFiles:     []string{"tp/inc"},
+		IncludeFiles:     []string{"tp/inc/lib.h", "tp/inc/lib_conf.h", "tp/cfg", "tp/lwip-2.1.2"},
 		SourceFiles:      []string{"tp/src/lib.c"},
 		SourceAsmFiles:   []string{"tp/src/startup.s"},
 	}}
@@ -889,7 +923,7 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 		MainLocation:      "Src",
 	}
 
-	if err := WriteCgenYmlSub(base, mx, bp); err != nil {
+	if err := WriteCgenYmlSub(DefaultConfig(), base, mx, bp); err != nil {
 		t.Fatalf("WriteCgenYmlSub error: %v", err)
 	}
 
@@ -899,9 +933,39 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 	}
 	content := string(data)
 
-	if !strings.Contains(content, "VALID_DEFINE") || strings.Contains(content, "1BAD") {
+	if !strings.Contains(content, "VALID_DEFINE") || strings.Contains(content, "1BAD") || strings.Contains(content, "2BAD") {
 		t.Errorf("define filtering failed in output: %s", content)
 	}
+	var cgen cbuild.CgenType
+	if err := yaml.Unmarshal(data, &cgen); err != nil {
+		t.Fatalf("parse cgen: %v", err)
+	}
+	if want := []string{"./include", "./Drivers/CMSIS/Include", "./MX_Device", "./tp/inc", "./tp/cfg", "./tp/lwip-2.1.2"}; !reflect.DeepEqual(cgen.GeneratorImport.AddPath, want) {
+		t.Errorf("add-path = %v, want %v", cgen.GeneratorImport.AddPath, want)
+	}
+	var groupFiles []string
+	for _, group := range cgen.GeneratorImport.Groups {
+		if group.Group == "IPLIB" {
+			for _, file := range group.Files {
+				groupFiles = append(groupFiles, file.File)
+			}
+		}
+	}
+	if want := []string{"./tp/src/lib.c", "./tp/src/startup.s", "./tp/inc/lib.h", "./tp/inc/lib_conf.h"}; !reflect.DeepEqual(groupFiles, want) {
+		t.Errorf("IPLIB files = %v, want %v", groupFiles, want)
+	}
+	var libraries []cbuild.CgenFilesType
+	for _, group := range cgen.GeneratorImport.Groups {
+		if group.Group == "Libraries" {
+			libraries = group.Files
+		}
+	}
+	if want := []cbuild.CgenFilesType{{File: "./tp/lib/libtp_math.a", Category: "library"}, {File: "./tp/lib/tp_any.a", Category: "library"}}; !reflect.DeepEqual(libraries, want) {
+		t.Errorf("Libraries files = %v, want %v", libraries, want)
+	}
+	if !strings.Contains(content, "- HSE_VALUE: \"8000000\"") {
+		t.Errorf("missing define value in output: %s", content)
+	}
 	if !strings.Contains(content, "CubeMX") {
 		t.Errorf("missing CubeMX group in output")
 	}
@@ -911,6 +975,130 @@ func Test_WriteCgenYmlSub(t *testing.T) {
 	if strings.Contains(content, "Templates") {
 		t.Errorf("filtered header path Templates should not appear")
 	}
+	if !strings.Contains(content, "script: ./STM32CubeIDE/STM32F429ZITX_FLASH.ld") || !strings.Contains(content, "for-compiler: GCC") {
+		t.Errorf("missing linker script in output: %s", content)
+	}
+}
+
+// Test_WriteCgenYmlSub_GenFiles checks that PreviousGenFiles locate the generated files of the advanced folder structure.
+func Test_WriteCgenYmlSub_GenFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "STM32CubeMX")
+	files := []string{
+		"MDK-ARM/startup_stm32f429xx.s",
+		"Core/Src/main.c",
+		"Core/Src/gpio.c",
+		"Core/Src/system_stm32f4xx.c",
+		"Core/Inc/main.h",
+		"Core/Inc/gpio.h",
+	}
+	for _, file := range files {
+		file = filepath.Join(base, file)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(""), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mx := MxprojectType{}
+	mx.PreviousUsedFiles.SourceFiles = []string{"../Core/Src/main.c"}
+	mx.PreviousGenFiles.AdvancedFolderStructure = "true"
+	mx.PreviousGenFiles.SourceFilesList = []string{"..\\Core\\Src\\main.c", "..\\Core\\Src\\gpio.c", "..\\Core\\Src\\missing.c"}
+	mx.PreviousGenFiles.HeaderFilesList = []string{"..\\Core\\Inc\\main.h", "..\\Core\\Inc\\gpio.h"}
+	mx.PreviousGenFiles.HeaderPathList = []string{"..\\Core\\Inc"}
+	mx.PreviousGenFiles.SourcePathList = []string{"..\\Core\\Src"}
+
+	// MainLocation does not match the folder structure, the system file is found by PreviousGenFiles
+	bp := BridgeParamType{Compiler: "AC6", CgenName: filepath.Join(tmpDir, "Cgen.yml"), MainLocation: "Src"}
+	if err := WriteCgenYmlSub(DefaultConfig(), base, mx, bp); err != nil {
+		t.Fatalf("WriteCgenYmlSub error: %v", err)
+	}
+
+	data, err := os.ReadFile(bp.CgenName)
+	if err != nil {
+		t.Fatalf("read cgen: %v", err)
+	}
+	var cgen cbuild.CgenType
+	if err := yaml.Unmarshal(data, &cgen); err != nil {
+		t.Fatalf("parse cgen: %v", err)
+	}
+	var groupFiles []string
+	for _, file := range cgen.GeneratorImport.Groups[0].Files {
+		groupFiles = append(groupFiles, file.File)
+	}
+	want := []string{"./Core/Src/main.c", "./Core/Src/gpio.c", "./Core/Inc/main.h", "./Core/Inc/gpio.h",
+		"./MDK-ARM/startup_stm32f429xx.s", "./Core/Src/system_stm32f4xx.c"}
+	if !reflect.DeepEqual(groupFiles, want) {
+		t.Errorf("CubeMX files = %v, want %v", groupFiles, want)
+	}
+	var categories []string
+	for _, file := range cgen.GeneratorImport.Groups[0].Files {
+		categories = append(categories, file.Category)
+	}
+	if want := []string{"sourceC", "sourceC", "header", "header", "sourceAsm", "sourceC"}; !reflect.DeepEqual(categories, want) {
+		t.Errorf("CubeMX categories = %v, want %v", categories, want)
+	}
+	if !slices.Contains(cgen.GeneratorImport.AddPath, "./Core/Inc") {
+		t.Errorf("add-path = %v, want ./Core/Inc", cgen.GeneratorImport.AddPath)
+	}
+}
+
+func Test_GetGenFilePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"..\\Core\\Src\\main.c":  "STM32CubeMX/Core/Src/main.c",
+		"../../CM7/Core/Inc":     "STM32CubeMX/CM7/Core/Inc",
+		"./Src/gpio.c":           "STM32CubeMX/Src/gpio.c",
+		"Secure/Core/Src/main.c": "STM32CubeMX/Secure/Core/Src/main.c",
+	}
+	for file, want := range tests {
+		if got := GetGenFilePath("STM32CubeMX", file); got != filepath.FromSlash(want) {
+			t.Errorf("GetGenFilePath(%v) = %v, want %v", file, got, want)
+		}
+	}
+}
+
+func Test_GetGenStartupFile(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	base := filepath.Join(outPath, "STM32CubeMX")
+	for _, file := range []string{
+		"CM7/Core/Src/main.c",
+		"CM7/Core/Startup/startup_stm32h745zitx.s",
+		"CM7/Core/Startup/startup_stm32h745zitx_CM7.s",
+		"STM32CubeIDE/CM7/Application/User/Startup/startup_stm32h745zitx.s",
+	} {
+		file = filepath.Join(base, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(""), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mx := MxprojectType{}
+	mx.PreviousGenFiles.SourcePathList = []string{"..\\..\\CM7\\Core\\Src"}
+	bp := BridgeParamType{Compiler: "GCC", CubeContextFolder: "CM7"}
+
+	// without the advanced folder structure the startup file is not in the generated folders
+	if got := GetGenStartupFile(outPath, mx, bp); got != "" {
+		t.Errorf("GetGenStartupFile() = %v, want none", got)
+	}
+	mx.PreviousGenFiles.AdvancedFolderStructure = "1"
+	if got, want := GetGenStartupFile(outPath, mx, bp), filepath.Join(base, "CM7", "Core", "Startup", "startup_stm32h745zitx_CM7.s"); got != want {
+		t.Errorf("GetGenStartupFile() = %v, want %v", got, want)
+	}
+	mx.PreviousGenFiles.SourcePathList = []string{"..\\Core\\Src"}
+	if got := GetGenStartupFile(outPath, mx, bp); got != "" {
+		t.Errorf("GetGenStartupFile() = %v, want none for missing folder", got)
+	}
 }
 
 // Test_WriteCgenYml validates multi-bridge invocation and skips errored FindMxProject contexts gracefully.
@@ -946,7 +1134,7 @@ func Test_WriteCgenYml(t *testing.T) {
 		{CubeContext: "Ctx2", Compiler: "GCC", CgenName: filepath.Join(tmpDir, "cgen2.yml"), MainLocation: "Src"},
 	}
 
-	if err := WriteCgenYml(base, all, params); err != nil {
+	if err := WriteCgenYml(DefaultConfig(), base, all, params); err != nil {
 		t.Fatalf("WriteCgenYml error: %v", err)
 	}
 	for i, p := range params {
@@ -982,7 +1170,7 @@ func Test_GetToolchain(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GetToolchain(tt.args.compiler)
+			got, err := GetToolchain(DefaultConfig(), tt.args.compiler)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetToolchain() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -1021,7 +1209,7 @@ func Test_GetRelativePathAdd(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GetRelativePathAdd(tt.args.outPath, tt.args.compiler)
+			got, err := GetRelativePathAdd(DefaultConfig(), tt.args.outPath, tt.args.compiler)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetRelativePathAdd() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -1061,7 +1249,7 @@ func Test_GetToolchainFolderPath(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GetToolchainFolderPath(tt.args.outPath, tt.args.compiler)
+			got, err := GetToolchainFolderPath(DefaultConfig(), tt.args.outPath, tt.args.compiler)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetToolchainFolderPath() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -1236,7 +1424,7 @@ func Test_GetStartupFile(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GetStartupFile(tt.args.outPath, tt.args.info)
+			got, err := GetStartupFile(DefaultConfig(), tt.args.outPath, tt.args.info)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetStartupFile() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -1271,7 +1459,7 @@ func Test_GetStartupFile_ApplicationUserStartupSelectedWhenMainLocationNotSrc(t
 		MainLocation:      "Flash",
 	}
 
-	got, err := GetStartupFile(outPath, info)
+	got, err := GetStartupFile(DefaultConfig(), outPath, info)
 	if err != nil {
 		t.Fatalf("GetStartupFile() error = %v", err)
 	}
@@ -1438,7 +1626,7 @@ func Test_GetSystemFile(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GetSystemFile(tt.args.outPath, tt.args.info)
+			got, err := GetSystemFile(DefaultConfig(), tt.args.outPath, tt.args.info)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetSystemFile() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 				return
@@ -1470,7 +1658,7 @@ func Test_GetSystemFile_UsesMainLocationFolder(t *testing.T) {
 		MainLocation: filepath.Join("Application", "User"),
 	}
 
-	got, err := GetSystemFile(outPath, info)
+	got, err := GetSystemFile(DefaultConfig(), outPath, info)
 	if err != nil {
 		t.Fatalf("GetSystemFile() error = %v", err)
 	}
@@ -1479,185 +1667,157 @@ func Test_GetSystemFile_UsesMainLocationFolder(t *testing.T) {
 	}
 }
 
-// func Test_GetLinkerScripts(t *testing.T) {
-// 	t.Parallel()
-
-// 	// Single core
-// 	outPathSC := "../../testdata/testExamples/STM32H7_SC/STM32CubeMX/device"
-// 	var infoScAC6 BridgeParamType
-// 	infoScAC6.Compiler = "AC6"
-// 	infoScAC6.ProjectType = "single-core"
-// 	infoScAC6.ForProjectPart = ""
-// 	var infoScGCC BridgeParamType
-// 	infoScGCC.Compiler = "GCC"
-// 	infoScGCC.ProjectType = "single-core"
-// 	infoScGCC.ForProjectPart = ""
-// 	var infoScCLANG BridgeParamType
-// 	infoScCLANG.Compiler = "CLANG"
-// 	infoScCLANG.ProjectType = "single-core"
-// 	infoScCLANG.ForProjectPart = ""
-// 	var infoScIAR BridgeParamType
-// 	infoScIAR.Compiler = "IAR"
-// 	infoScIAR.ProjectType = "single-core"
-// 	infoScIAR.ForProjectPart = ""
-
-// 	// Multi core
-// 	outPathDC := "../../testdata/testExamples/STM32H7_DC/STM32CubeMX/STM32H745BGTx"
-// 	var infoDcAC6 BridgeParamType
-// 	infoDcAC6.Compiler = "AC6"
-// 	infoDcAC6.ProjectType = "multi-core"
-// 	infoDcAC6.ForProjectPart = "CM4"
-// 	var infoDcGCC BridgeParamType
-// 	infoDcGCC.Compiler = "GCC"
-// 	infoDcGCC.ProjectType = "multi-core"
-// 	infoDcGCC.ForProjectPart = "CM7"
-// 	var infoDcCLANG BridgeParamType
-// 	infoDcCLANG.Compiler = "CLANG"
-// 	infoDcCLANG.ProjectType = "multi-core"
-// 	infoDcCLANG.ForProjectPart = "CM4"
-// 	var infoDcIAR BridgeParamType
-// 	infoDcIAR.Compiler = "IAR"
-// 	infoDcIAR.ProjectType = "multi-core"
-// 	infoDcIAR.ForProjectPart = "CM7"
-
-// 	// secure nonsecure
-// 	outPathTZ := "../../testdata/testExamples/STM32U5_TZ/STM32CubeMX/Board"
-// 	var infoTzAC6 BridgeParamType
-// 	infoTzAC6.Compiler = "AC6"
-// 	infoTzAC6.ProjectType = "trustzone"
-// 	infoTzAC6.ForProjectPart = "secure"
-// 	var infoTzGCC BridgeParamType
-// 	infoTzGCC.Compiler = "GCC"
-// 	infoTzGCC.ProjectType = "trustzone"
-// 	infoTzGCC.ForProjectPart = "non-secure"
-// 	var infoTzCLANG BridgeParamType
-// 	infoTzCLANG.Compiler = "CLANG"
-// 	infoTzCLANG.ProjectType = "trustzone"
-// 	infoTzCLANG.ForProjectPart = "secure"
-// 	var infoTzIAR BridgeParamType
-// 	infoTzIAR.Compiler = "IAR"
-// 	infoTzIAR.ProjectType = "trustzone"
-// 	infoTzIAR.ForProjectPart = "non-secure"
-
-// 	// invalid
-// 	outPathInv := "../../testdata/testExamples/STM32H7_DC/STM32CubeMX/STM32H745BGTx/invalid_folder"
-// 	var infoInv BridgeParamType
-// 	infoInv.Compiler = "AC6"
-// 	infoInv.ProjectType = "single-core"
-// 	infoInv.ForProjectPart = ""
-
-// 	type args struct {
-// 		outPath string
-// 		info    BridgeParamType
-// 	}
-// 	tests := []struct {
-// 		name    string
-// 		args    args
-// 		want    []string
-// 		wantErr bool
-// 	}{
-// 		{"test_sc_ac6", args{outPathSC, infoScAC6}, nil, false},
-// 		{
-// 			"test_sc_gcc", args{outPathSC, infoScGCC},
-// 			[]string{
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/STM32CubeIDE/STM32H743AGIX_FLASH.ld"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/STM32CubeIDE/STM32H743AGIX_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_sc_clang", args{outPathSC, infoScCLANG},
-// 			[]string{
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/STM32CubeIDE/STM32H743AGIX_FLASH.ld"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/STM32CubeIDE/STM32H743AGIX_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_sc_iar", args{outPathSC, infoScIAR},
-// 			[]string{
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/EWARM/stm32h743xg_flash.icf"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/EWARM/stm32h743xg_flash_rw_sram1.icf"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/EWARM/stm32h743xg_flash_rw_sram2.icf"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/EWARM/stm32h743xx_dtcmram.icf"),
-// 				filepath.Clean(outPathSC + "/STM32CubeMX/EWARM/stm32h743xx_sram1.icf"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_dc_ac6", args{outPathDC, infoDcAC6},
-// 			[]string{
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/MDK-ARM/stm32h745xg_flash_CM4.sct"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/MDK-ARM/stm32h745xx_sram2_CM4.sct"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_dc_gcc", args{outPathDC, infoDcGCC},
-// 			[]string{
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/STM32CubeIDE/CM7/STM32H745BGTX_FLASH.ld"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/STM32CubeIDE/CM7/STM32H745BGTX_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_dc_clang", args{outPathDC, infoDcCLANG},
-// 			[]string{
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/STM32CubeIDE/CM4/STM32H745BGTX_FLASH.ld"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/STM32CubeIDE/CM4/STM32H745BGTX_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_dc_iar", args{outPathDC, infoDcIAR},
-// 			[]string{
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/EWARM/stm32h745xg_flash_CM7.icf"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/EWARM/stm32h745xx_dtcmram_CM7.icf"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/EWARM/stm32h745xx_flash_rw_sram1_CM7.icf"),
-// 				filepath.Clean(outPathDC + "/STM32CubeMX/EWARM/stm32h745xx_sram1_CM7.icf"),
-// 			},
-// 			false,
-// 		},
-// 		{"test_tz_ac6", args{outPathTZ, infoTzAC6}, nil, false},
-// 		{
-// 			"test_tz_gcc", args{outPathTZ, infoTzGCC},
-// 			[]string{
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/STM32CubeIDE/NonSecure/STM32U585AIIXQ_FLASH.ld"),
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/STM32CubeIDE/NonSecure/STM32U585AIIXQ_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_tz_clang", args{outPathTZ, infoTzCLANG},
-// 			[]string{
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/STM32CubeIDE/Secure/STM32U585AIIXQ_FLASH.ld"),
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/STM32CubeIDE/Secure/STM32U585AIIXQ_RAM.ld"),
-// 			},
-// 			false,
-// 		},
-// 		{
-// 			"test_tz_iar", args{outPathTZ, infoTzIAR},
-// 			[]string{
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/EWARM/stm32u585xx_flash_ns.icf"),
-// 				filepath.Clean(outPathTZ + "/STM32CubeMX/EWARM/stm32u585xx_sram_ns.icf"),
-// 			},
-// 			false,
-// 		},
-
-// 		{"fail", args{outPathInv, infoInv}, nil, true}}
-
-// 	for _, tt := range tests {
-// 		tt := tt
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			t.Parallel()
-// 			got, err := GetLinkerScripts(tt.args.outPath, tt.args.info)
-// 			if (err != nil) != tt.wantErr {
-// 				t.Errorf("GetLinkerScripts() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
-// 				return
-// 			}
-// 			if !reflect.DeepEqual(got, tt.want) {
-// 				t.Errorf("GetLinkerScripts() %s = %v, want %v", tt.name, got, tt.want)
-// 			}
-// 		})
-// 	}
-// }
+func Test_GetLinkerScripts(t *testing.T) {
+	t.Parallel()
+
+	outPathDC := "../../testdata/testExamples/STM32H7_DC/STM32CubeMX/STM32H745BGTx"
+	infoDcCM7 := BridgeParamType{ProjectType: "multi-core", ForProjectPart: "CM7", CubeContext: "CortexM7", CubeContextFolder: "CM7"}
+	infoDcCM4 := BridgeParamType{ProjectType: "multi-core", ForProjectPart: "CM4", CubeContext: "CortexM4", CubeContextFolder: "CM4"}
+
+	outPathTZ := "../../testdata/testExamples/STM32U5_TZ/STM32CubeMX/Board"
+	infoTzS := BridgeParamType{ProjectType: "trustzone", ForProjectPart: "secure", CubeContext: "CortexM33S", CubeContextFolder: "Secure"}
+	infoTzNS := BridgeParamType{ProjectType: "trustzone", ForProjectPart: "non-secure", CubeContext: "CortexM33NS", CubeContextFolder: "NonSecure"}
+
+	tests := []struct {
+		name     string
+		outPath  string
+		info     BridgeParamType
+		compiler string
+		want     []string
+		wantErr  bool
+	}{
+		{"test_dc_ac6", outPathDC, infoDcCM7, "AC6", []string{"MDK-ARM/stm32h745xg_flash_CM7.sct", "MDK-ARM/stm32h745xx_sram1_CM7.sct"}, false},
+		{"test_dc_gcc", outPathDC, infoDcCM4, "GCC", []string{"STM32CubeIDE/CM4/STM32H745BGTX_FLASH.ld", "STM32CubeIDE/CM4/STM32H745BGTX_RAM.ld"}, false},
+		{"test_dc_clang", outPathDC, infoDcCM7, "CLANG", []string{"STM32CubeIDE/CM7/STM32H745BGTX_FLASH.ld", "STM32CubeIDE/CM7/STM32H745BGTX_RAM.ld"}, false},
+		{"test_dc_iar", outPathDC, infoDcCM4, "IAR", []string{"EWARM/stm32h745xg_flash_CM4.icf", "EWARM/stm32h745xx_flash_rw_sram2_CM4.icf", "EWARM/stm32h745xx_sram2_CM4.icf"}, false},
+
+		{"test_tz_ac6", outPathTZ, infoTzS, "AC6", nil, false},
+		{"test_tz_gcc", outPathTZ, infoTzNS, "GCC", []string{"STM32CubeIDE/NonSecure/STM32U585AIIXQ_FLASH.ld", "STM32CubeIDE/NonSecure/STM32U585AIIXQ_RAM.ld"}, false},
+		{"test_tz_clang", outPathTZ, infoTzS, "CLANG", []string{"STM32CubeIDE/Secure/STM32U585AIIXQ_FLASH.ld", "STM32CubeIDE/Secure/STM32U585AIIXQ_RAM.ld"}, false},
+		{"test_tz_iar_s", outPathTZ, infoTzS, "IAR", []string{"EWARM/stm32u585xx_flash_s.icf", "EWARM/stm32u585xx_sram_s.icf"}, false},
+		{"test_tz_iar_ns", outPathTZ, infoTzNS, "IAR", []string{"EWARM/stm32u585xx_flash_ns.icf", "EWARM/stm32u585xx_sram_ns.icf"}, false},
+
+		{"fail_compiler", outPathTZ, infoTzS, "XYZ", nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			info := tt.info
+			info.Compiler = tt.compiler
+			got, err := GetLinkerScripts(DefaultConfig(), tt.outPath, info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLinkerScripts() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			var want []string
+			for _, file := range tt.want {
+				want = append(want, filepath.Join(tt.outPath, "STM32CubeMX", file))
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GetLinkerScripts() %s = %v, want %v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func Test_GetLinkerScript(t *testing.T) {
+	t.Parallel()
+
+	outPathDC := "../../testdata/testExamples/STM32H7_DC/STM32CubeMX/STM32H745BGTx"
+	outPathTZ := "../../testdata/testExamples/STM32U5_TZ/STM32CubeMX/Board"
+
+	tests := []struct {
+		name    string
+		outPath string
+		info    BridgeParamType
+		want    string
+		wantErr bool
+	}{
+		{"test_dc_ac6", outPathDC, BridgeParamType{Compiler: "AC6", ProjectType: "multi-core", ForProjectPart: "CM4", CubeContextFolder: "CM4"}, "MDK-ARM/stm32h745xg_flash_CM4.sct", false},
+		{"test_dc_iar", outPathDC, BridgeParamType{Compiler: "IAR", ProjectType: "multi-core", ForProjectPart: "CM7", CubeContextFolder: "CM7"}, "EWARM/stm32h745xg_flash_CM7.icf", false},
+		{"test_dc_gcc", outPathDC, BridgeParamType{Compiler: "GCC", ProjectType: "multi-core", ForProjectPart: "CM7", CubeContextFolder: "CM7"}, "STM32CubeIDE/CM7/STM32H745BGTX_FLASH.ld", false},
+		{"test_tz_iar", outPathTZ, BridgeParamType{Compiler: "IAR", ProjectType: "trustzone", ForProjectPart: "non-secure", CubeContextFolder: "NonSecure"}, "EWARM/stm32u585xx_flash_ns.icf", false},
+		{"test_tz_clang", outPathTZ, BridgeParamType{Compiler: "CLANG", ProjectType: "trustzone", ForProjectPart: "secure", CubeContextFolder: "Secure"}, "STM32CubeIDE/Secure/STM32U585AIIXQ_FLASH.ld", false},
+		{"test_tz_ac6", outPathTZ, BridgeParamType{Compiler: "AC6", ProjectType: "trustzone", ForProjectPart: "secure", CubeContextFolder: "Secure"}, "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetLinkerScript(DefaultConfig(), tt.outPath, tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLinkerScript() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			want := ""
+			if tt.want != "" {
+				want = filepath.Join(tt.outPath, "STM32CubeMX", tt.want)
+			}
+			if got != want {
+				t.Errorf("GetLinkerScript() %s = %v, want %v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+// Test_exitHelper is the process supervised by Test_Supervise
+func Test_exitHelper(t *testing.T) {
+	if os.Getenv("CBRIDGE_EXIT_HELPER") == "" {
+		t.Skip("helper process only")
+	}
+	os.Exit(3)
+}
+
+// Test_Supervise is not parallel as the watch loop uses the package state
+func Test_Supervise(t *testing.T) {
+	workDir := t.TempDir()
+	cgenName := filepath.Join(workDir, "test.cgen.yml")
+	if err := os.WriteFile(cgenlog.GetPath(cgenName), nil, 0600); err != nil { // log of the previous run
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	cubeMX := &CubeMX{bridgeParams: []BridgeParamType{{CgenName: cgenName}}}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_exitHelper$") //nolint
+	cmd.Env = append(os.Environ(), "CBRIDGE_EXIT_HELPER=1")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("cmd.Start() error = %v", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- cubeMX.Supervise(workDir, cmd) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Supervise() error = %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		cubeMX.Stop()
+		t.Fatal("Supervise() did not return after CubeMX ended")
+	}
+
+	content, err := os.ReadFile(cgenlog.GetPath(cgenName))
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(content), "CubeMX exited with code 3") {
+		t.Errorf("Supervise() cgen log = %q, want exit code 3", content)
+	}
+}
+
+func Test_getHeaderFolder(t *testing.T) {
+	t.Parallel()
+
+	for file, want := range map[string]string{
+		"./STM32CubeMX/Middlewares/lwip-2.1.2/include/lwipopts.h": "./STM32CubeMX/Middlewares/lwip-2.1.2/include",
+		"./foo.h":     ".",
+		"foo.h":       ".",
+		"inc/foo.hpp": "inc",
+	} {
+		if got := getHeaderFolder(file); got != want {
+			t.Errorf("getHeaderFolder(%v) = %v, want %v", file, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+	"github.com/open-cmsis-pack/generator-bridge/internal/utils"
+)
+
+// ProjectLayout selects the CubeMX project type generated for a compiler
+type ProjectLayout string
+
+const (
+	// LayoutIDE generates the IDE project of the compiler (MDK-ARM, EWARM, STM32CubeIDE)
+	LayoutIDE ProjectLayout = "ide"
+	// LayoutCMake generates the CMake project of ST Open Toolchain for GCC and CLANG
+	LayoutCMake ProjectLayout = "cmake"
+)
+
+// ParseProjectLayout checks the layout option
+func ParseProjectLayout(layout string) (ProjectLayout, error) {
+	switch ProjectLayout(layout) {
+	case LayoutIDE, LayoutCMake:
+		return ProjectLayout(layout), nil
+	}
+	return "", errors.New("unknown project layout '" + layout + "', use 'ide' or 'cmake'")
+}
+
+// toolchainType describes where CubeMX places the files of a toolchain
+type toolchainType struct {
+	compiler   string        // compiler of the csolution project
+	layout     ProjectLayout // project layout the entry applies to
+	toolchain  string        // "project toolchain" of the CubeMX script
+	folder     string        // toolchain folder below STM32CubeMX, "" for the project root
+	pathAdd    string        // folder the .mxproject file paths are relative to
+	filesID    string        // .mxproject section with the files used by the toolchain
+	perContext bool          // toolchain folder has a subfolder per core or security state
+	startup    []string      // startup file folders below the toolchain (and context) folder, first existing is taken
+}
+
+// defaultToolchains lists the built-in compiler and layout combinations
+var defaultToolchains = []toolchainType{
+	{compiler: "AC6", layout: LayoutIDE, toolchain: "MDK-ARM V5", folder: "MDK-ARM", pathAdd: "MDK-ARM", filesID: "PreviousUsedKeilFiles", startup: []string{""}},
+	{compiler: "IAR", layout: LayoutIDE, toolchain: "EWARM", folder: "EWARM", pathAdd: "EWARM", filesID: "PreviousUsedIarFiles", startup: []string{""}},
+	{compiler: "GCC", layout: LayoutIDE, toolchain: "STM32CubeIDE", folder: "STM32CubeIDE", filesID: "PreviousUsedCubeIDEFiles", perContext: true, startup: []string{"Application/User/Startup", "Application/Startup"}},
+	{compiler: "CLANG", layout: LayoutIDE, toolchain: "STM32CubeIDE", folder: "STM32CubeIDE", filesID: "PreviousUsedCubeIDEFiles", perContext: true, startup: []string{"Application/User/Startup", "Application/Startup"}},
+	{compiler: "GCC", layout: LayoutCMake, toolchain: "CMake", filesID: "PreviousUsedCMakeFiles", perContext: true, startup: []string{""}},
+	{compiler: "CLANG", layout: LayoutCMake, toolchain: "CMake", filesID: "PreviousUsedCMakeFiles", perContext: true, startup: []string{""}},
+}
+
+// getToolchainType returns the toolchain descriptor of the compiler for the selected layout
+func (cfg *Config) getToolchainType(compiler string) (toolchainType, error) {
+	for _, toolchain := range cfg.toolchains {
+		if toolchain.compiler == compiler && toolchain.layout == cfg.layout {
+			return toolchain, nil
+		}
+	}
+	if cfg.layout != LayoutIDE {
+		return toolchainType{}, errors.New("unknown compiler '" + compiler + "' for project layout '" + string(cfg.layout) + "'")
+	}
+	return toolchainType{}, errors.New("unknown compiler '" + compiler + "'")
+}
+
+// SetToolchains applies the toolchains section of global.generator.yml to the built-in
+// table, the previous table is kept on error
+func (c *CubeMX) SetToolchains(entries []generator.ToolchainType) error {
+	table, err := newToolchains(entries)
+	if err != nil {
+		return err
+	}
+	c.setConfig(func(config *Config) { config.toolchains = table })
+	return nil
+}
+
+// newToolchains overrides the non-empty fields of a known compiler and layout or adds
+// a new entry. The entries are applied to a copy of the built-in table.
+func newToolchains(entries []generator.ToolchainType) ([]toolchainType, error) {
+	table := slices.Clone(defaultToolchains)
+	for _, entry := range entries {
+		if entry.Compiler == "" {
+			return nil, errors.New("toolchains entry without compiler in global.generator.yml")
+		}
+		layout := LayoutIDE
+		if entry.Layout != "" {
+			var err error
+			layout, err = ParseProjectLayout(entry.Layout)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		index := -1
+		for i, toolchain := range table {
+			if toolchain.compiler == entry.Compiler && toolchain.layout == layout {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if entry.Toolchain == "" || entry.Section == "" {
+				return nil, errors.New("toolchains entry for new compiler '" + entry.Compiler + "' needs toolchain and mxproject-section")
+			}
+			table = append(table, toolchainType{compiler: entry.Compiler, layout: layout, startup: []string{""}})
+			index = len(table) - 1
+		}
+
+		toolchain := &table[index]
+		if entry.Toolchain != "" {
+			toolchain.toolchain = entry.Toolchain
+		}
+		if entry.Folder != "" {
+			// the .mxproject paths of an IDE project are relative to its toolchain folder
+			if toolchain.pathAdd == toolchain.folder {
+				toolchain.pathAdd = entry.Folder
+			}
+			toolchain.folder = entry.Folder
+		}
+		if entry.PathAdd != "" {
+			toolchain.pathAdd = entry.PathAdd
+		}
+		if entry.Section != "" {
+			toolchain.filesID = entry.Section
+		}
+		if len(entry.Startup) > 0 {
+			toolchain.startup = entry.Startup
+		}
+	}
+	return table, nil
+}
+
+// GetProjectFolderPath returns the STM32CubeMX project folder containing the .ioc file
+func GetProjectFolderPath(outPath string) string {
+	if filepath.Base(outPath) != "STM32CubeMX" {
+		return filepath.Join(outPath, "STM32CubeMX")
+	}
+	return filepath.Clean(outPath)
+}
+
+// getStartupFolder returns the first existing startup folder of the toolchain
+func getStartupFolder(toolchainFolder string, toolchain toolchainType, bridgeParams BridgeParamType) string {
+	if toolchain.perContext {
+		toolchainFolder = filepath.Join(toolchainFolder, bridgeParams.CubeContextFolder)
+	}
+	startupFolder := toolchainFolder
+	for _, folder := range toolchain.startup {
+		startupFolder = filepath.Join(toolchainFolder, folder)
+		if utils.DirExists(startupFolder) {
+			break
+		}
+	}
+	return startupFolder
+}
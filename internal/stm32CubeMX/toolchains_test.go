@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package stm32cubemx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/open-cmsis-pack/generator-bridge/internal/generator"
+)
+
+func TestParseProjectLayout(t *testing.T) {
+	t.Parallel()
+
+	for _, layout := range []string{"ide", "cmake"} {
+		if got, err := ParseProjectLayout(layout); err != nil || string(got) != layout {
+			t.Errorf("ParseProjectLayout(%v) = %v, %v", layout, got, err)
+		}
+	}
+	if _, err := ParseProjectLayout("makefile"); err == nil {
+		t.Errorf("ParseProjectLayout() error = nil for unknown layout")
+	}
+}
+
+func Test_CMakeLayout(t *testing.T) {
+	t.Parallel()
+
+	cfg := *DefaultConfig()
+	cfg.layout = LayoutCMake
+
+	outPath := t.TempDir()
+	base := filepath.Join(outPath, "STM32CubeMX")
+	files := []string{
+		"startup_stm32f429xx.s",
+		"STM32F429XX_FLASH.ld",
+		"CMakeLists.txt",
+		"Core/Src/main.c",
+		"Core/Src/system_stm32f4xx.c",
+		"Drivers/CMSIS/Device/ST/STM32F4xx/Source/Templates/gcc/startup_stm32f401xc.s",
+		"Drivers/CMSIS/Device/ST/STM32F4xx/Source/Templates/gcc/linker/STM32F401XC_FLASH.ld",
+	}
+	for _, file := range files {
+		file = filepath.Join(base, file)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(""), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, compiler := range []string{"GCC", "CLANG"} {
+		if got, err := GetToolchain(&cfg, compiler); err != nil || got != "CMake" {
+			t.Errorf("GetToolchain(%v) = %v, %v, want CMake", compiler, got, err)
+		}
+		if got, err := GetToolchainFolderPath(&cfg, outPath, compiler); err != nil || got != base {
+			t.Errorf("GetToolchainFolderPath(%v) = %v, %v, want %v", compiler, got, err, base)
+		}
+		if got, err := GetRelativePathAdd(&cfg, outPath, compiler); err != nil || got != "STM32CubeMX" {
+			t.Errorf("GetRelativePathAdd(%v) = %v, %v, want STM32CubeMX", compiler, got, err)
+		}
+		if got, err := GetPreviousUsedFilesID(&cfg, compiler); err != nil || got != "PreviousUsedCMakeFiles" {
+			t.Errorf("GetPreviousUsedFilesID(%v) = %v, %v, want PreviousUsedCMakeFiles", compiler, got, err)
+		}
+	}
+	for _, compiler := range []string{"AC6", "IAR"} {
+		if _, err := GetToolchain(&cfg, compiler); err == nil {
+			t.Errorf("GetToolchain(%v) error = nil for CMake layout", compiler)
+		}
+	}
+
+	info := BridgeParamType{Compiler: "GCC", ProjectType: "single-core", MainLocation: "Core/Src"}
+	if got, err := GetStartupFile(&cfg, outPath, info); err != nil || got != filepath.Join(base, "startup_stm32f429xx.s") {
+		t.Errorf("GetStartupFile() = %v, %v", got, err)
+	}
+	if got, err := GetSystemFile(&cfg, outPath, info); err != nil || got != filepath.Join(base, "Core", "Src", "system_stm32f4xx.c") {
+		t.Errorf("GetSystemFile() = %v, %v", got, err)
+	}
+	if got, err := GetLinkerScript(&cfg, outPath, info); err != nil || got != filepath.Join(base, "STM32F429XX_FLASH.ld") {
+		t.Errorf("GetLinkerScript() = %v, %v", got, err)
+	}
+}
+
+func Test_SetToolchains(t *testing.T) {
+	t.Parallel()
+
+	cubeMX := &CubeMX{}
+	err := cubeMX.SetToolchains([]generator.ToolchainType{
+		{Compiler: "GCC", Toolchain: "STM32CubeIDE V2", Startup: []string{"Application/Startup"}},
+		{Compiler: "GCC", Layout: "cmake", Section: "PreviousUsedCmakeFiles"},
+		{Compiler: "ATfE", Toolchain: "CMake", Section: "PreviousUsedCMakeFiles"},
+	})
+	if err != nil {
+		t.Fatalf("SetToolchains() error = %v", err)
+	}
+	cfg := cubeMX.getConfig()
+
+	if got, err := GetToolchain(cfg, "GCC"); err != nil || got != "STM32CubeIDE V2" {
+		t.Errorf("GetToolchain(GCC) = %v, %v", got, err)
+	}
+	if got, err := GetToolchainFolderPath(cfg, "STM32CubeMX", "GCC"); err != nil || got != filepath.Join("STM32CubeMX", "STM32CubeIDE") {
+		t.Errorf("GetToolchainFolderPath(GCC) = %v, %v", got, err)
+	}
+	if got, err := GetPreviousUsedFilesID(cfg, "CLANG"); err != nil || got != "PreviousUsedCubeIDEFiles" {
+		t.Errorf("GetPreviousUsedFilesID(CLANG) = %v, %v", got, err)
+	}
+	if got, err := GetToolchainFolderPath(cfg, "STM32CubeMX", "ATfE"); err != nil || got != "STM32CubeMX" {
+		t.Errorf("GetToolchainFolderPath(ATfE) = %v, %v", got, err)
+	}
+
+	cmake := *cfg
+	cmake.layout = LayoutCMake
+	got, err := GetPreviousUsedFilesID(&cmake, "GCC")
+	if err != nil || got != "PreviousUsedCmakeFiles" {
+		t.Errorf("GetPreviousUsedFilesID(GCC) for cmake = %v, %v", got, err)
+	}
+
+	// the .mxproject paths follow a changed MDK-ARM folder unless set explicitly
+	err = cubeMX.SetToolchains([]generator.ToolchainType{
+		{Compiler: "AC6", Folder: "Keil"},
+		{Compiler: "IAR", Folder: "IAR", PathAdd: "EWARM"},
+	})
+	if err != nil {
+		t.Fatalf("SetToolchains() error = %v", err)
+	}
+	cfg = cubeMX.getConfig()
+	if got, err := GetRelativePathAdd(cfg, "STM32CubeMX", "AC6"); err != nil || got != "Keil" {
+		t.Errorf("GetRelativePathAdd(AC6) = %v, %v", got, err)
+	}
+	if got, err := GetRelativePathAdd(cfg, "STM32CubeMX", "IAR"); err != nil || got != "EWARM" {
+		t.Errorf("GetRelativePathAdd(IAR) = %v, %v", got, err)
+	}
+	// each pass starts from the built-in table
+	if got, err := GetToolchain(cfg, "GCC"); err != nil || got != "STM32CubeIDE" {
+		t.Errorf("GetToolchain(GCC) after second pass = %v, %v", got, err)
+	}
+	if err = cubeMX.SetToolchains(nil); err != nil || !reflect.DeepEqual(cubeMX.getConfig().toolchains, defaultToolchains) {
+		t.Errorf("SetToolchains(nil) = %v, did not restore the built-in table", err)
+	}
+
+	for _, entry := range []generator.ToolchainType{
+		{Toolchain: "CMake"},
+		{Compiler: "GCC", Layout: "makefile"},
+		{Compiler: "TASKING", Folder: "TASKING"},
+	} {
+		if err := cubeMX.SetToolchains([]generator.ToolchainType{entry}); err == nil {
+			t.Errorf("SetToolchains(%+v) error = nil", entry)
+		}
+	}
+	if err = cubeMX.SetToolchains([]generator.ToolchainType{{Compiler: "AC6", Folder: "Keil"}}); err != nil {
+		t.Fatalf("SetToolchains() error = %v", err)
+	}
+	if err = cubeMX.SetToolchains([]generator.ToolchainType{{Compiler: "AC6", Folder: "MDK"}, {Toolchain: "CMake"}}); err == nil {
+		t.Errorf("SetToolchains() error = nil for entry without compiler")
+	}
+	if got, err := GetRelativePathAdd(cubeMX.getConfig(), "STM32CubeMX", "AC6"); err != nil || got != "Keil" {
+		t.Errorf("SetToolchains() error replaced the table, GetRelativePathAdd(AC6) = %v, %v", got, err)
+	}
+}
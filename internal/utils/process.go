@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// ProcessRunning checks if a process with the given pid exists
+func ProcessRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false // on Windows the process does not exist
+	}
+	if runtime.GOOS == "windows" {
+		_ = proc.Release()
+		return true
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM) // EPERM: runs as another user
+}
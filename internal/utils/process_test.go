@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestProcessRunning(t *testing.T) {
+	t.Parallel()
+
+	if !ProcessRunning(os.Getpid()) {
+		t.Errorf("ProcessRunning(%d) = false for the own process", os.Getpid())
+	}
+	if ProcessRunning(0) || ProcessRunning(-1) {
+		t.Errorf("ProcessRunning() = true for an invalid pid")
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$") //nolint
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("cmd.Run() error = %v", err)
+	}
+	if ProcessRunning(cmd.Process.Pid) {
+		t.Errorf("ProcessRunning(%d) = true for an ended process", cmd.Process.Pid)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -122,3 +124,61 @@ func ConvertFilenameRel(outPath, file string) (string, error) {
 
 	return file, nil
 }
+
+// CompareVersions compares two dot separated version strings numerically.
+// It returns -1, 0 or +1. Non numeric parts compare as strings.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimSpace(a), ".")
+	partsB := strings.Split(strings.TrimSpace(b), ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var partA, partB string
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		numA, errA := strconv.Atoi(partA)
+		numB, errB := strconv.Atoi(partB)
+		if partA == "" {
+			numA, errA = 0, nil
+		}
+		if partB == "" {
+			numB, errB = 0, nil
+		}
+		if errA == nil && errB == nil {
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if c := strings.Compare(partA, partB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// RotateFile renames file to file.1, file.1 to file.2 and so on. The oldest of the
+// keep backups is removed. A missing file is not an error.
+func RotateFile(file string, keep int) error {
+	if !FileExists(file) {
+		return nil
+	}
+	if keep < 1 {
+		return os.Remove(file)
+	}
+	backup := func(i int) string { return file + "." + strconv.Itoa(i) }
+	if err := os.Remove(backup(keep)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for i := keep - 1; i > 0; i-- {
+		if err := os.Rename(backup(i), backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(file, backup(1))
+}
@@ -8,6 +8,7 @@ package utils
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
@@ -165,3 +166,55 @@ func TestConvertFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"equal", "16.0", "16.0", 0},
+		{"shorter", "16", "16.0", 0},
+		{"minor", "16.1", "16.0", 1},
+		{"numeric", "9.0", "16.0", -1},
+		{"patch", "25.03.10", "25.03.9", 1},
+		{"text", "1.0.0-beta", "1.0.0-rc", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareVersions(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotateFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "session.log")
+	if err := RotateFile(file, 2); err != nil {
+		t.Errorf("RotateFile() of missing file error = %v", err)
+	}
+
+	for _, content := range []string{"first", "second", "third"} {
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := RotateFile(file, 2); err != nil {
+			t.Fatalf("RotateFile() error = %v", err)
+		}
+	}
+
+	if FileExists(file) {
+		t.Errorf("RotateFile() left %v", file)
+	}
+	for backup, want := range map[string]string{file + ".1": "third", file + ".2": "second"} {
+		data, err := os.ReadFile(backup)
+		if err != nil || string(data) != want {
+			t.Errorf("RotateFile() %v = %q, %v, want %q", backup, data, err, want)
+		}
+	}
+	if FileExists(file + ".3") {
+		t.Errorf("RotateFile() kept more than 2 backups")
+	}
+}
@@ -1,5 +1,8 @@
 @echo off
-REM Script launch Infineon Device Configurator.
+REM Launch Infineon Device Configurator.
+REM Discovery of the tool, launch and error reporting (*.cgen.log) are done by cbridge.
 
-echo Platform is not supported for Infineon Device Configurator!
-exit /b 1
+REM Path to .cbuild-gen.idx.yml file
+set idxFile=%1
+
+"%~dp0cbridge.exe" %idxFile%
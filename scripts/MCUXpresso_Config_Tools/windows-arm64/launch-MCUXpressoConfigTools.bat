@@ -1,5 +1,8 @@
 @echo off
-REM Script launch MCUXpresso Config Tools in OpenCMSIS generator mode.
+REM Launch MCUXpresso Config Tools in OpenCMSIS generator mode. It supports MCUXpresso Config Tools v16 and newer.
+REM Discovery of the tools, launch and error reporting (*.cgen.log) are done by cbridge.
 
-echo Platform is not supported for MCUXpresso Config Tools in OpenCMSIS generator mode!
-exit /b 1
+REM Path to .cbuild-gen.idx.yml file
+set idxFile=%1
+
+"%~dp0cbridge.exe" %idxFile%
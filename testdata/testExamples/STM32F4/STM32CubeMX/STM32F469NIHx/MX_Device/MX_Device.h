@@ -1,6 +1,6 @@
 /******************************************************************************
  * File Name   : MX_Device.h
- * Date        : 10/04/2025 12:37:57
+ * Date        : 17/10/2026 08:45:03
  * Description : STM32Cube MX parameter definitions
  * Note        : This file is generated with a generator out of the
  *               STM32CubeMX project and its generated files (DO NOT EDIT!)
@@ -122,6 +122,54 @@
 #define MX_I2C1_SDA_GPIO_Speed                  GPIO_SPEED_FREQ_VERY_HIGH
 #define MX_I2C1_SDA_GPIO_AF                     GPIO_AF4_I2C1
 
+/*------------------------------ SAI1           -----------------------------*/
+#define MX_SAI1                                 1
+
+/* Virtual mode */
+#define MX_SAI1_VM                              VM_MASTER
+#define MX_SAI1_VM_MASTER                       1
+
+/* Blocks */
+#define MX_SAI1_BLOCK_A_VM                      VM_MASTER
+
+/* Pins */
+
+/* SAI1_FS_A */
+#define MX_SAI1_FS_A_Pin                        PE4
+#define MX_SAI1_FS_A_GPIO_Pin                   GPIO_PIN_4
+#define MX_SAI1_FS_A_GPIOx                      GPIOE
+#define MX_SAI1_FS_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI1_FS_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI1_FS_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_FS_A_GPIO_AF                    GPIO_AF6_SAI1
+
+/* SAI1_MCLK_A */
+#define MX_SAI1_MCLK_A_Pin                      PE2
+#define MX_SAI1_MCLK_A_GPIO_Pin                 GPIO_PIN_2
+#define MX_SAI1_MCLK_A_GPIOx                    GPIOE
+#define MX_SAI1_MCLK_A_GPIO_Mode                GPIO_MODE_AF_PP
+#define MX_SAI1_MCLK_A_GPIO_PuPd                GPIO_NOPULL
+#define MX_SAI1_MCLK_A_GPIO_Speed               GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_MCLK_A_GPIO_AF                  GPIO_AF6_SAI1
+
+/* SAI1_SCK_A */
+#define MX_SAI1_SCK_A_Pin                       PE5
+#define MX_SAI1_SCK_A_GPIO_Pin                  GPIO_PIN_5
+#define MX_SAI1_SCK_A_GPIOx                     GPIOE
+#define MX_SAI1_SCK_A_GPIO_Mode                 GPIO_MODE_AF_PP
+#define MX_SAI1_SCK_A_GPIO_PuPd                 GPIO_NOPULL
+#define MX_SAI1_SCK_A_GPIO_Speed                GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_SCK_A_GPIO_AF                   GPIO_AF6_SAI1
+
+/* SAI1_SD_A */
+#define MX_SAI1_SD_A_Pin                        PE6
+#define MX_SAI1_SD_A_GPIO_Pin                   GPIO_PIN_6
+#define MX_SAI1_SD_A_GPIOx                      GPIOE
+#define MX_SAI1_SD_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI1_SD_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI1_SD_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_SD_A_GPIO_AF                    GPIO_AF6_SAI1
+
 /*------------------------------ SDIO           -----------------------------*/
 #define MX_SDIO                                 1
 
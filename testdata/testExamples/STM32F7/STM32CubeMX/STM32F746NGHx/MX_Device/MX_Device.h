@@ -1,6 +1,6 @@
 /******************************************************************************
  * File Name   : MX_Device.h
- * Date        : 10/04/2025 12:35:18
+ * Date        : 17/10/2026 09:02:10
  * Description : STM32Cube MX parameter definitions
  * Note        : This file is generated with a generator out of the
  *               STM32CubeMX project and its generated files (DO NOT EDIT!)
@@ -13,6 +13,54 @@
 #define MX_DEVICE_VERSION                       0x01000000
 
 
+/*------------------------------ ADC3           -----------------------------*/
+#define MX_ADC3                                 1
+
+/* Channels */
+#define MX_ADC3_CHANNEL_0                       1
+#define MX_ADC3_CHANNEL_4                       1
+#define MX_ADC3_CHANNEL_5                       1
+#define MX_ADC3_CHANNEL_6                       1
+#define MX_ADC3_CHANNEL_7                       1
+#define MX_ADC3_CHANNEL_8                       1
+
+/* Pins */
+
+/* ADC3_IN4 */
+#define MX_ADC3_IN4_Pin                         PF6
+#define MX_ADC3_IN4_GPIO_Pin                    GPIO_PIN_6
+#define MX_ADC3_IN4_GPIOx                       GPIOF
+#define MX_ADC3_IN4_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN4_GPIO_PuPd                   GPIO_NOPULL
+
+/* ADC3_IN5 */
+#define MX_ADC3_IN5_Pin                         PF7
+#define MX_ADC3_IN5_GPIO_Pin                    GPIO_PIN_7
+#define MX_ADC3_IN5_GPIOx                       GPIOF
+#define MX_ADC3_IN5_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN5_GPIO_PuPd                   GPIO_NOPULL
+
+/* ADC3_IN6 */
+#define MX_ADC3_IN6_Pin                         PF8
+#define MX_ADC3_IN6_GPIO_Pin                    GPIO_PIN_8
+#define MX_ADC3_IN6_GPIOx                       GPIOF
+#define MX_ADC3_IN6_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN6_GPIO_PuPd                   GPIO_NOPULL
+
+/* ADC3_IN7 */
+#define MX_ADC3_IN7_Pin                         PF9
+#define MX_ADC3_IN7_GPIO_Pin                    GPIO_PIN_9
+#define MX_ADC3_IN7_GPIOx                       GPIOF
+#define MX_ADC3_IN7_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN7_GPIO_PuPd                   GPIO_NOPULL
+
+/* ADC3_IN8 */
+#define MX_ADC3_IN8_Pin                         PF10
+#define MX_ADC3_IN8_GPIO_Pin                    GPIO_PIN_10
+#define MX_ADC3_IN8_GPIOx                       GPIOF
+#define MX_ADC3_IN8_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN8_GPIO_PuPd                   GPIO_NOPULL
+
 /*------------------------------ ETH            -----------------------------*/
 #define MX_ETH                                  1
 
@@ -99,6 +147,23 @@
 #define MX_ETH_TX_EN_GPIO_Speed                 GPIO_SPEED_FREQ_VERY_HIGH
 #define MX_ETH_TX_EN_GPIO_AF                    GPIO_AF11_ETH
 
+/*------------------------------ EXTI           -----------------------------*/
+#define MX_EXTI                                 1
+
+/* Pins */
+
+/* GPXTI13 */
+#define MX_GPXTI13_Pin                          PI13
+#define MX_GPXTI13_GPIO_Pin                     GPIO_PIN_13
+#define MX_GPXTI13_GPIOx                        GPIOI
+#define MX_GPXTI13_GPIO_Mode                    GPIO_MODE_EVT_RISING
+
+/* GPXTI6 */
+#define MX_GPXTI6_Pin                           PD6
+#define MX_GPXTI6_GPIO_Pin                      GPIO_PIN_6
+#define MX_GPXTI6_GPIOx                         GPIOD
+#define MX_GPXTI6_GPIO_Mode                     GPIO_MODE_EVT_RISING
+
 /*------------------------------ I2C1           -----------------------------*/
 #define MX_I2C1                                 1
 
@@ -159,6 +224,137 @@
 #define MX_I2C3_SDA_GPIO_Speed                  GPIO_SPEED_FREQ_VERY_HIGH
 #define MX_I2C3_SDA_GPIO_AF                     GPIO_AF4_I2C3
 
+/*------------------------------ QUADSPI        -----------------------------*/
+#define MX_QUADSPI                              1
+
+/* Parameters */
+#define MX_QUADSPI_FLASH_SIZE                   24
+
+/* Pins */
+
+/* QUADSPI_BK1_IO0 */
+#define MX_QUADSPI_BK1_IO0_Pin                  PD11
+#define MX_QUADSPI_BK1_IO0_GPIO_Pin             GPIO_PIN_11
+#define MX_QUADSPI_BK1_IO0_GPIOx                GPIOD
+#define MX_QUADSPI_BK1_IO0_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO0_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO0_GPIO_Speed           GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_BK1_IO0_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_IO1 */
+#define MX_QUADSPI_BK1_IO1_Pin                  PD12
+#define MX_QUADSPI_BK1_IO1_GPIO_Pin             GPIO_PIN_12
+#define MX_QUADSPI_BK1_IO1_GPIOx                GPIOD
+#define MX_QUADSPI_BK1_IO1_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO1_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO1_GPIO_Speed           GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_BK1_IO1_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_IO2 */
+#define MX_QUADSPI_BK1_IO2_Pin                  PE2
+#define MX_QUADSPI_BK1_IO2_GPIO_Pin             GPIO_PIN_2
+#define MX_QUADSPI_BK1_IO2_GPIOx                GPIOE
+#define MX_QUADSPI_BK1_IO2_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO2_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO2_GPIO_Speed           GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_BK1_IO2_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_IO3 */
+#define MX_QUADSPI_BK1_IO3_Pin                  PD13
+#define MX_QUADSPI_BK1_IO3_GPIO_Pin             GPIO_PIN_13
+#define MX_QUADSPI_BK1_IO3_GPIOx                GPIOD
+#define MX_QUADSPI_BK1_IO3_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO3_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO3_GPIO_Speed           GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_BK1_IO3_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_NCS */
+#define MX_QUADSPI_BK1_NCS_Pin                  PB6
+#define MX_QUADSPI_BK1_NCS_GPIO_Pin             GPIO_PIN_6
+#define MX_QUADSPI_BK1_NCS_GPIOx                GPIOB
+#define MX_QUADSPI_BK1_NCS_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_NCS_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_NCS_GPIO_Speed           GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_BK1_NCS_GPIO_AF              GPIO_AF10_QUADSPI
+
+/* QUADSPI_CLK */
+#define MX_QUADSPI_CLK_Pin                      PB2
+#define MX_QUADSPI_CLK_GPIO_Pin                 GPIO_PIN_2
+#define MX_QUADSPI_CLK_GPIOx                    GPIOB
+#define MX_QUADSPI_CLK_GPIO_Mode                GPIO_MODE_AF_PP
+#define MX_QUADSPI_CLK_GPIO_PuPd                GPIO_NOPULL
+#define MX_QUADSPI_CLK_GPIO_Speed               GPIO_SPEED_FREQ_VERY_HIGH
+#define MX_QUADSPI_CLK_GPIO_AF                  GPIO_AF9_QUADSPI
+
+/*------------------------------ RTC            -----------------------------*/
+#define MX_RTC                                  1
+
+/* Alarms */
+#define MX_RTC_ALARM_A                          1
+#define MX_RTC_ALARM_B                          1
+
+
+/*------------------------------ SAI2           -----------------------------*/
+#define MX_SAI2                                 1
+
+/* Virtual mode */
+#define MX_SAI2_VM                              VM_MASTER
+#define MX_SAI2_VM_MASTER                       1
+
+/* Peripheral Clock Frequency */
+#define MX_SAI2_PERIPH_CLOCK_FREQ               192000000
+
+/* Blocks */
+#define MX_SAI2_BLOCK_A_VM                      VM_MASTER
+#define MX_SAI2_BLOCK_B_VM                      VM_SLAVE
+
+/* Pins */
+
+/* SAI2_FS_A */
+#define MX_SAI2_FS_A_Pin                        PI7
+#define MX_SAI2_FS_A_GPIO_Pin                   GPIO_PIN_7
+#define MX_SAI2_FS_A_GPIOx                      GPIOI
+#define MX_SAI2_FS_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI2_FS_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI2_FS_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI2_FS_A_GPIO_AF                    GPIO_AF10_SAI2
+
+/* SAI2_MCLK_A */
+#define MX_SAI2_MCLK_A_Pin                      PI4
+#define MX_SAI2_MCLK_A_GPIO_Pin                 GPIO_PIN_4
+#define MX_SAI2_MCLK_A_GPIOx                    GPIOI
+#define MX_SAI2_MCLK_A_GPIO_Mode                GPIO_MODE_AF_PP
+#define MX_SAI2_MCLK_A_GPIO_PuPd                GPIO_NOPULL
+#define MX_SAI2_MCLK_A_GPIO_Speed               GPIO_SPEED_FREQ_LOW
+#define MX_SAI2_MCLK_A_GPIO_AF                  GPIO_AF10_SAI2
+
+/* SAI2_SCK_A */
+#define MX_SAI2_SCK_A_Pin                       PI5
+#define MX_SAI2_SCK_A_GPIO_Pin                  GPIO_PIN_5
+#define MX_SAI2_SCK_A_GPIOx                     GPIOI
+#define MX_SAI2_SCK_A_GPIO_Mode                 GPIO_MODE_AF_PP
+#define MX_SAI2_SCK_A_GPIO_PuPd                 GPIO_NOPULL
+#define MX_SAI2_SCK_A_GPIO_Speed                GPIO_SPEED_FREQ_LOW
+#define MX_SAI2_SCK_A_GPIO_AF                   GPIO_AF10_SAI2
+
+/* SAI2_SD_A */
+#define MX_SAI2_SD_A_Pin                        PI6
+#define MX_SAI2_SD_A_GPIO_Pin                   GPIO_PIN_6
+#define MX_SAI2_SD_A_GPIOx                      GPIOI
+#define MX_SAI2_SD_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI2_SD_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI2_SD_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI2_SD_A_GPIO_AF                    GPIO_AF10_SAI2
+
+/* SAI2_SD_B */
+#define MX_SAI2_SD_B_Pin                        PG10
+#define MX_SAI2_SD_B_GPIO_Pin                   GPIO_PIN_10
+#define MX_SAI2_SD_B_GPIOx                      GPIOG
+#define MX_SAI2_SD_B_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI2_SD_B_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI2_SD_B_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI2_SD_B_GPIO_AF                    GPIO_AF10_SAI2
+
 /*------------------------------ SDMMC1         -----------------------------*/
 #define MX_SDMMC1                               1
 
@@ -259,6 +455,95 @@
 #define MX_SPI2_SCK_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
 #define MX_SPI2_SCK_GPIO_AF                     GPIO_AF5_SPI2
 
+/*------------------------------ TIM1           -----------------------------*/
+#define MX_TIM1                                 1
+
+/* Channels */
+#define MX_TIM1_CH1_MODE                        PWM_Generation
+
+/* Pins */
+
+/* TIM1_CH1 */
+#define MX_TIM1_CH1_Pin                         PA8
+#define MX_TIM1_CH1_GPIO_Pin                    GPIO_PIN_8
+#define MX_TIM1_CH1_GPIOx                       GPIOA
+#define MX_TIM1_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM1_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM1_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH1_GPIO_AF                     GPIO_AF1_TIM1
+
+/*------------------------------ TIM12          -----------------------------*/
+#define MX_TIM12                                1
+
+/* Channels */
+#define MX_TIM12_CH1_MODE                       PWM_Generation
+
+/* Pins */
+
+/* TIM12_CH1 */
+#define MX_TIM12_CH1_Pin                        PH6
+#define MX_TIM12_CH1_GPIO_Pin                   GPIO_PIN_6
+#define MX_TIM12_CH1_GPIOx                      GPIOH
+#define MX_TIM12_CH1_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM12_CH1_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM12_CH1_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM12_CH1_GPIO_AF                    GPIO_AF9_TIM12
+
+/*------------------------------ TIM2           -----------------------------*/
+#define MX_TIM2                                 1
+
+/* Channels */
+#define MX_TIM2_CH1_MODE                        PWM_Generation
+
+/* Pins */
+
+/* TIM2_CH1_ETR */
+#define MX_TIM2_CH1_ETR_Pin                     PA15
+#define MX_TIM2_CH1_ETR_GPIO_Pin                GPIO_PIN_15
+#define MX_TIM2_CH1_ETR_GPIOx                   GPIOA
+#define MX_TIM2_CH1_ETR_GPIO_Mode               GPIO_MODE_AF_PP
+#define MX_TIM2_CH1_ETR_GPIO_PuPd               GPIO_NOPULL
+#define MX_TIM2_CH1_ETR_GPIO_Speed              GPIO_SPEED_FREQ_LOW
+#define MX_TIM2_CH1_ETR_GPIO_AF                 GPIO_AF1_TIM2
+
+/*------------------------------ TIM3           -----------------------------*/
+#define MX_TIM3                                 1
+
+/* Channels */
+#define MX_TIM3_CH1_MODE                        PWM_Generation
+
+/* Pins */
+
+/* TIM3_CH1 */
+#define MX_TIM3_CH1_Pin                         PB4
+#define MX_TIM3_CH1_GPIO_Pin                    GPIO_PIN_4
+#define MX_TIM3_CH1_GPIOx                       GPIOB
+#define MX_TIM3_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM3_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM3_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM3_CH1_GPIO_AF                     GPIO_AF2_TIM3
+
+/*------------------------------ TIM5           -----------------------------*/
+#define MX_TIM5                                 1
+
+/* Channels */
+#define MX_TIM5_CH4_MODE                        PWM_Generation
+
+/* Pins */
+
+/* TIM5_CH4 */
+#define MX_TIM5_CH4_Pin                         PI0
+#define MX_TIM5_CH4_GPIO_Pin                    GPIO_PIN_0
+#define MX_TIM5_CH4_GPIOx                       GPIOI
+#define MX_TIM5_CH4_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM5_CH4_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM5_CH4_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM5_CH4_GPIO_AF                     GPIO_AF2_TIM5
+
+/*------------------------------ TIM8           -----------------------------*/
+#define MX_TIM8                                 1
+
+
 /*------------------------------ USART1         -----------------------------*/
 #define MX_USART1                               1
 
@@ -323,6 +608,11 @@
 #define MX_USB_OTG_FS_VM                        Host_Only
 #define MX_USB_OTG_FS_Host_Only                 1
 
+/* Interrupts */
+#define MX_USB_OTG_FS_IRQ                       OTG_FS_IRQn
+#define MX_USB_OTG_FS_IRQ_PRIORITY              0
+#define MX_USB_OTG_FS_IRQ_SUBPRIORITY           0
+
 /* Pins */
 
 /* USB_OTG_FS_DM */
@@ -13,6 +13,77 @@
 #define MX_DEVICE_VERSION                       0x01000000
 
 
+/*------------------------------ ADC1           -----------------------------*/
+#define MX_ADC1                                 1
+
+/* Peripheral Clock Frequency */
+#define MX_ADC1_PERIPH_CLOCK_FREQ               150000000
+
+/* Channels */
+#define MX_ADC1_CHANNEL_7                       1
+
+
+/*------------------------------ ADC2           -----------------------------*/
+#define MX_ADC2                                 1
+
+/* Peripheral Clock Frequency */
+#define MX_ADC2_PERIPH_CLOCK_FREQ               150000000
+
+/* Channels */
+#define MX_ADC2_CHANNEL_5                       1
+
+/* Pins */
+
+/* ADC2_IN5 */
+#define MX_ADC2_IN5_Pin                         PC4
+#define MX_ADC2_IN5_GPIO_Pin                    GPIO_PIN_4
+#define MX_ADC2_IN5_GPIOx                       GPIOC
+#define MX_ADC2_IN5_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC2_IN5_GPIO_PuPd                   GPIO_NOPULL
+
+/*------------------------------ ADC3           -----------------------------*/
+#define MX_ADC3                                 1
+
+/* Peripheral Clock Frequency */
+#define MX_ADC3_PERIPH_CLOCK_FREQ               150000000
+
+/* Channels */
+#define MX_ADC3_CHANNEL_9                       1
+
+/* Pins */
+
+/* ADC3_IN4 */
+#define MX_ADC3_IN4_Pin                         PE7
+#define MX_ADC3_IN4_GPIO_Pin                    GPIO_PIN_7
+#define MX_ADC3_IN4_GPIOx                       GPIOE
+#define MX_ADC3_IN4_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC3_IN4_GPIO_PuPd                   GPIO_NOPULL
+
+/*------------------------------ ADC4           -----------------------------*/
+#define MX_ADC4                                 1
+
+/* Peripheral Clock Frequency */
+#define MX_ADC4_PERIPH_CLOCK_FREQ               150000000
+
+/* Channels */
+#define MX_ADC4_CHANNEL_12                      1
+
+/* Pins */
+
+/* ADC4_IN1 */
+#define MX_ADC4_IN1_Pin                         PE14
+#define MX_ADC4_IN1_GPIO_Pin                    GPIO_PIN_14
+#define MX_ADC4_IN1_GPIOx                       GPIOE
+#define MX_ADC4_IN1_GPIO_Mode                   GPIO_MODE_ANALOG
+#define MX_ADC4_IN1_GPIO_PuPd                   GPIO_NOPULL
+
+/*------------------------------ DAC1           -----------------------------*/
+#define MX_DAC1                                 1
+
+/* Channels */
+#define MX_DAC1_CHANNEL_2                       1
+
+
 /*------------------------------ FDCAN1         -----------------------------*/
 #define MX_FDCAN1                               1
 
@@ -106,6 +177,271 @@
 #define MX_SPI2_SCK_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
 #define MX_SPI2_SCK_GPIO_AF                     GPIO_AF5_SPI2
 
+/*------------------------------ TIM1           -----------------------------*/
+#define MX_TIM1                                 1
+
+/* Channels */
+#define MX_TIM1_CH1_MODE                        Output_Compare
+#define MX_TIM1_CH2_MODE                        Output_Compare
+#define MX_TIM1_CH3_MODE                        Output_Compare
+
+/* Pins */
+
+/* TIM1_BKIN */
+#define MX_TIM1_BKIN_Pin                        PE15
+#define MX_TIM1_BKIN_GPIO_Pin                   GPIO_PIN_15
+#define MX_TIM1_BKIN_GPIOx                      GPIOE
+#define MX_TIM1_BKIN_GPIO_Mode                  GPIO_MODE_AF_OD
+#define MX_TIM1_BKIN_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM1_BKIN_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_BKIN_GPIO_AF                    GPIO_AF2_TIM1
+
+/* TIM1_CH1 */
+#define MX_TIM1_CH1_Pin                         PE9
+#define MX_TIM1_CH1_GPIO_Pin                    GPIO_PIN_9
+#define MX_TIM1_CH1_GPIOx                       GPIOE
+#define MX_TIM1_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM1_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM1_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH1_GPIO_AF                     GPIO_AF2_TIM1
+
+/* TIM1_CH1N */
+#define MX_TIM1_CH1N_Pin                        PE8
+#define MX_TIM1_CH1N_GPIO_Pin                   GPIO_PIN_8
+#define MX_TIM1_CH1N_GPIOx                      GPIOE
+#define MX_TIM1_CH1N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM1_CH1N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM1_CH1N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH1N_GPIO_AF                    GPIO_AF2_TIM1
+
+/* TIM1_CH2 */
+#define MX_TIM1_CH2_Pin                         PE11
+#define MX_TIM1_CH2_GPIO_Pin                    GPIO_PIN_11
+#define MX_TIM1_CH2_GPIOx                       GPIOE
+#define MX_TIM1_CH2_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM1_CH2_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM1_CH2_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH2_GPIO_AF                     GPIO_AF2_TIM1
+
+/* TIM1_CH2N */
+#define MX_TIM1_CH2N_Pin                        PE10
+#define MX_TIM1_CH2N_GPIO_Pin                   GPIO_PIN_10
+#define MX_TIM1_CH2N_GPIOx                      GPIOE
+#define MX_TIM1_CH2N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM1_CH2N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM1_CH2N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH2N_GPIO_AF                    GPIO_AF2_TIM1
+
+/* TIM1_CH3 */
+#define MX_TIM1_CH3_Pin                         PE13
+#define MX_TIM1_CH3_GPIO_Pin                    GPIO_PIN_13
+#define MX_TIM1_CH3_GPIOx                       GPIOE
+#define MX_TIM1_CH3_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM1_CH3_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM1_CH3_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH3_GPIO_AF                     GPIO_AF2_TIM1
+
+/* TIM1_CH3N */
+#define MX_TIM1_CH3N_Pin                        PE12
+#define MX_TIM1_CH3N_GPIO_Pin                   GPIO_PIN_12
+#define MX_TIM1_CH3N_GPIOx                      GPIOE
+#define MX_TIM1_CH3N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM1_CH3N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM1_CH3N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM1_CH3N_GPIO_AF                    GPIO_AF2_TIM1
+
+/*------------------------------ TIM2           -----------------------------*/
+#define MX_TIM2                                 1
+
+/* Channels */
+#define MX_TIM2_CH1_MODE                        Output_Compare
+#define MX_TIM2_CH2_MODE                        Output_Compare
+#define MX_TIM2_CH3_MODE                        Output_Compare
+
+/* Pins */
+
+/* TIM2_CH1 */
+#define MX_TIM2_CH1_Pin                         PA0
+#define MX_TIM2_CH1_GPIO_Pin                    GPIO_PIN_0
+#define MX_TIM2_CH1_GPIOx                       GPIOA
+#define MX_TIM2_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM2_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM2_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM2_CH1_GPIO_AF                     GPIO_AF1_TIM2
+
+/* TIM2_CH2 */
+#define MX_TIM2_CH2_Pin                         PD4
+#define MX_TIM2_CH2_GPIO_Pin                    GPIO_PIN_4
+#define MX_TIM2_CH2_GPIOx                       GPIOD
+#define MX_TIM2_CH2_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM2_CH2_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM2_CH2_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM2_CH2_GPIO_AF                     GPIO_AF2_TIM2
+
+/* TIM2_CH3 */
+#define MX_TIM2_CH3_Pin                         PD7
+#define MX_TIM2_CH3_GPIO_Pin                    GPIO_PIN_7
+#define MX_TIM2_CH3_GPIOx                       GPIOD
+#define MX_TIM2_CH3_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM2_CH3_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM2_CH3_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM2_CH3_GPIO_AF                     GPIO_AF2_TIM2
+
+/*------------------------------ TIM3           -----------------------------*/
+#define MX_TIM3                                 1
+
+/* Channels */
+#define MX_TIM3_CH1_MODE                        Output_Compare
+#define MX_TIM3_CH2_MODE                        Output_Compare
+
+/* Pins */
+
+/* TIM3_CH1 */
+#define MX_TIM3_CH1_Pin                         PE2
+#define MX_TIM3_CH1_GPIO_Pin                    GPIO_PIN_2
+#define MX_TIM3_CH1_GPIOx                       GPIOE
+#define MX_TIM3_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM3_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM3_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM3_CH1_GPIO_AF                     GPIO_AF2_TIM3
+
+/* TIM3_CH2 */
+#define MX_TIM3_CH2_Pin                         PE3
+#define MX_TIM3_CH2_GPIO_Pin                    GPIO_PIN_3
+#define MX_TIM3_CH2_GPIOx                       GPIOE
+#define MX_TIM3_CH2_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM3_CH2_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM3_CH2_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM3_CH2_GPIO_AF                     GPIO_AF2_TIM3
+
+/* TIM3_ETR */
+#define MX_TIM3_ETR_Pin                         PD2
+#define MX_TIM3_ETR_GPIO_Pin                    GPIO_PIN_2
+#define MX_TIM3_ETR_GPIOx                       GPIOD
+#define MX_TIM3_ETR_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM3_ETR_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM3_ETR_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM3_ETR_GPIO_AF                     GPIO_AF2_TIM3
+
+/*------------------------------ TIM5           -----------------------------*/
+#define MX_TIM5                                 1
+
+/* Channels */
+#define MX_TIM5_CH1_MODE                        Output_Compare
+#define MX_TIM5_CH2_MODE                        Output_Compare
+#define MX_TIM5_CH3_MODE                        Output_Compare
+
+/* Pins */
+
+/* TIM5_CH1 */
+#define MX_TIM5_CH1_Pin                         PF6
+#define MX_TIM5_CH1_GPIO_Pin                    GPIO_PIN_6
+#define MX_TIM5_CH1_GPIOx                       GPIOF
+#define MX_TIM5_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM5_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM5_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM5_CH1_GPIO_AF                     GPIO_AF6_TIM5
+
+/* TIM5_CH2 */
+#define MX_TIM5_CH2_Pin                         PF7
+#define MX_TIM5_CH2_GPIO_Pin                    GPIO_PIN_7
+#define MX_TIM5_CH2_GPIOx                       GPIOF
+#define MX_TIM5_CH2_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM5_CH2_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM5_CH2_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM5_CH2_GPIO_AF                     GPIO_AF6_TIM5
+
+/* TIM5_CH3 */
+#define MX_TIM5_CH3_Pin                         PF8
+#define MX_TIM5_CH3_GPIO_Pin                    GPIO_PIN_8
+#define MX_TIM5_CH3_GPIOx                       GPIOF
+#define MX_TIM5_CH3_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM5_CH3_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM5_CH3_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM5_CH3_GPIO_AF                     GPIO_AF6_TIM5
+
+/* TIM5_ETR */
+#define MX_TIM5_ETR_Pin                         PD11
+#define MX_TIM5_ETR_GPIO_Pin                    GPIO_PIN_11
+#define MX_TIM5_ETR_GPIOx                       GPIOD
+#define MX_TIM5_ETR_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM5_ETR_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM5_ETR_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM5_ETR_GPIO_AF                     GPIO_AF1_TIM5
+
+/*------------------------------ TIM8           -----------------------------*/
+#define MX_TIM8                                 1
+
+/* Channels */
+#define MX_TIM8_CH1_MODE                        Output_Compare
+#define MX_TIM8_CH2_MODE                        Output_Compare
+#define MX_TIM8_CH3_MODE                        Output_Compare
+
+/* Pins */
+
+/* TIM8_BKIN */
+#define MX_TIM8_BKIN_Pin                        PB7
+#define MX_TIM8_BKIN_GPIO_Pin                   GPIO_PIN_7
+#define MX_TIM8_BKIN_GPIOx                      GPIOB
+#define MX_TIM8_BKIN_GPIO_Mode                  GPIO_MODE_AF_OD
+#define MX_TIM8_BKIN_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM8_BKIN_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_BKIN_GPIO_AF                    GPIO_AF5_TIM8
+
+/* TIM8_CH1 */
+#define MX_TIM8_CH1_Pin                         PC6
+#define MX_TIM8_CH1_GPIO_Pin                    GPIO_PIN_6
+#define MX_TIM8_CH1_GPIOx                       GPIOC
+#define MX_TIM8_CH1_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM8_CH1_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM8_CH1_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH1_GPIO_AF                     GPIO_AF4_TIM8
+
+/* TIM8_CH1N */
+#define MX_TIM8_CH1N_Pin                        PC10
+#define MX_TIM8_CH1N_GPIO_Pin                   GPIO_PIN_10
+#define MX_TIM8_CH1N_GPIOx                      GPIOC
+#define MX_TIM8_CH1N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM8_CH1N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM8_CH1N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH1N_GPIO_AF                    GPIO_AF4_TIM8
+
+/* TIM8_CH2 */
+#define MX_TIM8_CH2_Pin                         PC7
+#define MX_TIM8_CH2_GPIO_Pin                    GPIO_PIN_7
+#define MX_TIM8_CH2_GPIOx                       GPIOC
+#define MX_TIM8_CH2_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM8_CH2_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM8_CH2_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH2_GPIO_AF                     GPIO_AF4_TIM8
+
+/* TIM8_CH2N */
+#define MX_TIM8_CH2N_Pin                        PC11
+#define MX_TIM8_CH2N_GPIO_Pin                   GPIO_PIN_11
+#define MX_TIM8_CH2N_GPIOx                      GPIOC
+#define MX_TIM8_CH2N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM8_CH2N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM8_CH2N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH2N_GPIO_AF                    GPIO_AF4_TIM8
+
+/* TIM8_CH3 */
+#define MX_TIM8_CH3_Pin                         PC8
+#define MX_TIM8_CH3_GPIO_Pin                    GPIO_PIN_8
+#define MX_TIM8_CH3_GPIOx                       GPIOC
+#define MX_TIM8_CH3_GPIO_Mode                   GPIO_MODE_AF_PP
+#define MX_TIM8_CH3_GPIO_PuPd                   GPIO_NOPULL
+#define MX_TIM8_CH3_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH3_GPIO_AF                     GPIO_AF4_TIM8
+
+/* TIM8_CH3N */
+#define MX_TIM8_CH3N_Pin                        PC12
+#define MX_TIM8_CH3N_GPIO_Pin                   GPIO_PIN_12
+#define MX_TIM8_CH3N_GPIOx                      GPIOC
+#define MX_TIM8_CH3N_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_TIM8_CH3N_GPIO_PuPd                  GPIO_NOPULL
+#define MX_TIM8_CH3N_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_TIM8_CH3N_GPIO_AF                    GPIO_AF4_TIM8
+
 /*------------------------------ USART1         -----------------------------*/
 #define MX_USART1                               1
 
@@ -1,6 +1,6 @@
 /******************************************************************************
  * File Name   : MX_Device.h
- * Date        : 10/04/2025 12:26:34
+ * Date        : 17/10/2026 08:45:03
  * Description : STM32Cube MX parameter definitions
  * Note        : This file is generated with a generator out of the
  *               STM32CubeMX project and its generated files (DO NOT EDIT!)
@@ -13,6 +13,16 @@
 #define MX_DEVICE_VERSION                       0x01000000
 
 
+/*------------------------------ ADC1           -----------------------------*/
+#define MX_ADC1                                 1
+
+/* Peripheral Clock Frequency */
+#define MX_ADC1_PERIPH_CLOCK_FREQ               75000000
+
+/* Channels */
+#define MX_ADC1_CHANNEL_1                       1
+
+
 /*------------------------------ ETH            -----------------------------*/
 #define MX_ETH                                  1
 
@@ -99,6 +109,16 @@
 #define MX_ETH_TX_EN_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
 #define MX_ETH_TX_EN_GPIO_AF                    GPIO_AF11_ETH
 
+/*------------------------------ EXTI           -----------------------------*/
+#define MX_EXTI                                 1
+
+/* Pins */
+
+/* GPXTI8 */
+#define MX_GPXTI8_Pin                           PI8
+#define MX_GPXTI8_GPIO_Pin                      GPIO_PIN_8
+#define MX_GPXTI8_GPIOx                         GPIOI
+
 /*------------------------------ I2C1           -----------------------------*/
 #define MX_I2C1                                 1
 
@@ -129,6 +149,165 @@
 #define MX_I2C1_SDA_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
 #define MX_I2C1_SDA_GPIO_AF                     GPIO_AF4_I2C1
 
+/*------------------------------ QUADSPI        -----------------------------*/
+#define MX_QUADSPI                              1
+
+/* Peripheral Clock Frequency */
+#define MX_QUADSPI_PERIPH_CLOCK_FREQ            200000000
+
+/* Pins */
+
+/* QUADSPI_BK1_IO0 */
+#define MX_QUADSPI_BK1_IO0_Pin                  PF8
+#define MX_QUADSPI_BK1_IO0_GPIO_Pin             GPIO_PIN_8
+#define MX_QUADSPI_BK1_IO0_GPIOx                GPIOF
+#define MX_QUADSPI_BK1_IO0_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO0_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO0_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK1_IO0_GPIO_AF              GPIO_AF10_QUADSPI
+
+/* QUADSPI_BK1_IO1 */
+#define MX_QUADSPI_BK1_IO1_Pin                  PF9
+#define MX_QUADSPI_BK1_IO1_GPIO_Pin             GPIO_PIN_9
+#define MX_QUADSPI_BK1_IO1_GPIOx                GPIOF
+#define MX_QUADSPI_BK1_IO1_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO1_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO1_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK1_IO1_GPIO_AF              GPIO_AF10_QUADSPI
+
+/* QUADSPI_BK1_IO2 */
+#define MX_QUADSPI_BK1_IO2_Pin                  PF7
+#define MX_QUADSPI_BK1_IO2_GPIO_Pin             GPIO_PIN_7
+#define MX_QUADSPI_BK1_IO2_GPIOx                GPIOF
+#define MX_QUADSPI_BK1_IO2_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO2_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO2_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK1_IO2_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_IO3 */
+#define MX_QUADSPI_BK1_IO3_Pin                  PF6
+#define MX_QUADSPI_BK1_IO3_GPIO_Pin             GPIO_PIN_6
+#define MX_QUADSPI_BK1_IO3_GPIOx                GPIOF
+#define MX_QUADSPI_BK1_IO3_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_IO3_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_IO3_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK1_IO3_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK1_NCS */
+#define MX_QUADSPI_BK1_NCS_Pin                  PG6
+#define MX_QUADSPI_BK1_NCS_GPIO_Pin             GPIO_PIN_6
+#define MX_QUADSPI_BK1_NCS_GPIOx                GPIOG
+#define MX_QUADSPI_BK1_NCS_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK1_NCS_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK1_NCS_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK1_NCS_GPIO_AF              GPIO_AF10_QUADSPI
+
+/* QUADSPI_BK2_IO0 */
+#define MX_QUADSPI_BK2_IO0_Pin                  PH2
+#define MX_QUADSPI_BK2_IO0_GPIO_Pin             GPIO_PIN_2
+#define MX_QUADSPI_BK2_IO0_GPIOx                GPIOH
+#define MX_QUADSPI_BK2_IO0_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK2_IO0_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK2_IO0_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK2_IO0_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK2_IO1 */
+#define MX_QUADSPI_BK2_IO1_Pin                  PH3
+#define MX_QUADSPI_BK2_IO1_GPIO_Pin             GPIO_PIN_3
+#define MX_QUADSPI_BK2_IO1_GPIOx                GPIOH
+#define MX_QUADSPI_BK2_IO1_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK2_IO1_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK2_IO1_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK2_IO1_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK2_IO2 */
+#define MX_QUADSPI_BK2_IO2_Pin                  PG9
+#define MX_QUADSPI_BK2_IO2_GPIO_Pin             GPIO_PIN_9
+#define MX_QUADSPI_BK2_IO2_GPIOx                GPIOG
+#define MX_QUADSPI_BK2_IO2_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK2_IO2_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK2_IO2_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK2_IO2_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_BK2_IO3 */
+#define MX_QUADSPI_BK2_IO3_Pin                  PG14
+#define MX_QUADSPI_BK2_IO3_GPIO_Pin             GPIO_PIN_14
+#define MX_QUADSPI_BK2_IO3_GPIOx                GPIOG
+#define MX_QUADSPI_BK2_IO3_GPIO_Mode            GPIO_MODE_AF_PP
+#define MX_QUADSPI_BK2_IO3_GPIO_PuPd            GPIO_NOPULL
+#define MX_QUADSPI_BK2_IO3_GPIO_Speed           GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_BK2_IO3_GPIO_AF              GPIO_AF9_QUADSPI
+
+/* QUADSPI_CLK */
+#define MX_QUADSPI_CLK_Pin                      PB2
+#define MX_QUADSPI_CLK_GPIO_Pin                 GPIO_PIN_2
+#define MX_QUADSPI_CLK_GPIOx                    GPIOB
+#define MX_QUADSPI_CLK_GPIO_Mode                GPIO_MODE_AF_PP
+#define MX_QUADSPI_CLK_GPIO_PuPd                GPIO_NOPULL
+#define MX_QUADSPI_CLK_GPIO_Speed               GPIO_SPEED_FREQ_LOW
+#define MX_QUADSPI_CLK_GPIO_AF                  GPIO_AF9_QUADSPI
+
+/*------------------------------ SAI1           -----------------------------*/
+#define MX_SAI1                                 1
+
+/* Virtual mode */
+#define MX_SAI1_VM                              VM_MASTER
+#define MX_SAI1_VM_MASTER                       1
+
+/* Peripheral Clock Frequency */
+#define MX_SAI1_PERIPH_CLOCK_FREQ               133333333.33333333
+
+/* Blocks */
+#define MX_SAI1_BLOCK_A_VM                      VM_MASTER
+#define MX_SAI1_BLOCK_B_VM                      VM_SLAVE
+
+/* Pins */
+
+/* SAI1_FS_A */
+#define MX_SAI1_FS_A_Pin                        PE4
+#define MX_SAI1_FS_A_GPIO_Pin                   GPIO_PIN_4
+#define MX_SAI1_FS_A_GPIOx                      GPIOE
+#define MX_SAI1_FS_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI1_FS_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI1_FS_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_FS_A_GPIO_AF                    GPIO_AF6_SAI1
+
+/* SAI1_MCLK_A */
+#define MX_SAI1_MCLK_A_Pin                      PG7
+#define MX_SAI1_MCLK_A_GPIO_Pin                 GPIO_PIN_7
+#define MX_SAI1_MCLK_A_GPIOx                    GPIOG
+#define MX_SAI1_MCLK_A_GPIO_Mode                GPIO_MODE_AF_PP
+#define MX_SAI1_MCLK_A_GPIO_PuPd                GPIO_NOPULL
+#define MX_SAI1_MCLK_A_GPIO_Speed               GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_MCLK_A_GPIO_AF                  GPIO_AF6_SAI1
+
+/* SAI1_SCK_A */
+#define MX_SAI1_SCK_A_Pin                       PE5
+#define MX_SAI1_SCK_A_GPIO_Pin                  GPIO_PIN_5
+#define MX_SAI1_SCK_A_GPIOx                     GPIOE
+#define MX_SAI1_SCK_A_GPIO_Mode                 GPIO_MODE_AF_PP
+#define MX_SAI1_SCK_A_GPIO_PuPd                 GPIO_NOPULL
+#define MX_SAI1_SCK_A_GPIO_Speed                GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_SCK_A_GPIO_AF                   GPIO_AF6_SAI1
+
+/* SAI1_SD_A */
+#define MX_SAI1_SD_A_Pin                        PE6
+#define MX_SAI1_SD_A_GPIO_Pin                   GPIO_PIN_6
+#define MX_SAI1_SD_A_GPIOx                      GPIOE
+#define MX_SAI1_SD_A_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI1_SD_A_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI1_SD_A_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_SD_A_GPIO_AF                    GPIO_AF6_SAI1
+
+/* SAI1_SD_B */
+#define MX_SAI1_SD_B_Pin                        PE3
+#define MX_SAI1_SD_B_GPIO_Pin                   GPIO_PIN_3
+#define MX_SAI1_SD_B_GPIOx                      GPIOE
+#define MX_SAI1_SD_B_GPIO_Mode                  GPIO_MODE_AF_PP
+#define MX_SAI1_SD_B_GPIO_PuPd                  GPIO_NOPULL
+#define MX_SAI1_SD_B_GPIO_Speed                 GPIO_SPEED_FREQ_LOW
+#define MX_SAI1_SD_B_GPIO_AF                    GPIO_AF6_SAI1
+
 /*------------------------------ SDMMC1         -----------------------------*/
 #define MX_SDMMC1                               1
 
@@ -1,6 +1,6 @@
 /******************************************************************************
  * File Name   : MX_Device.h
- * Date        : 10/04/2025 12:13:44
+ * Date        : 17/10/2026 08:45:03
  * Description : STM32Cube MX parameter definitions
  * Note        : This file is generated with a generator out of the
  *               STM32CubeMX project and its generated files (DO NOT EDIT!)
@@ -73,6 +73,28 @@
 #define MX_I2C2_SDA_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
 #define MX_I2C2_SDA_GPIO_AF                     GPIO_AF4_I2C2
 
+/*------------------------------ OCTOSPI1       -----------------------------*/
+#define MX_OCTOSPI1                             1
+
+/* Peripheral Clock Frequency */
+#define MX_OCTOSPI1_PERIPH_CLOCK_FREQ           160000000
+
+/* Parameters */
+#define MX_OCTOSPI1_DEVICE_SIZE                 23
+#define MX_OCTOSPI1_MEMORY_TYPE                 HAL_OSPI_MEMTYPE_APMEMORY
+
+
+/*------------------------------ OCTOSPI2       -----------------------------*/
+#define MX_OCTOSPI2                             1
+
+/* Peripheral Clock Frequency */
+#define MX_OCTOSPI2_PERIPH_CLOCK_FREQ           160000000
+
+/* Parameters */
+#define MX_OCTOSPI2_DEVICE_SIZE                 26
+#define MX_OCTOSPI2_MEMORY_TYPE                 HAL_OSPI_MEMTYPE_MACRONIX
+
+
 /*------------------------------ SPI2           -----------------------------*/
 #define MX_SPI2                                 1
 
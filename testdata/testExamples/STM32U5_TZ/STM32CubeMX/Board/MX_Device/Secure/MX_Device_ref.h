@@ -73,6 +73,28 @@
 #define MX_I2C2_SDA_GPIO_Speed                  GPIO_SPEED_FREQ_LOW
 #define MX_I2C2_SDA_GPIO_AF                     GPIO_AF4_I2C2
 
+/*------------------------------ OCTOSPI1       -----------------------------*/
+#define MX_OCTOSPI1                             1
+
+/* Peripheral Clock Frequency */
+#define MX_OCTOSPI1_PERIPH_CLOCK_FREQ           160000000
+
+/* Parameters */
+#define MX_OCTOSPI1_DEVICE_SIZE                 23
+#define MX_OCTOSPI1_MEMORY_TYPE                 HAL_OSPI_MEMTYPE_APMEMORY
+
+
+/*------------------------------ OCTOSPI2       -----------------------------*/
+#define MX_OCTOSPI2                             1
+
+/* Peripheral Clock Frequency */
+#define MX_OCTOSPI2_PERIPH_CLOCK_FREQ           160000000
+
+/* Parameters */
+#define MX_OCTOSPI2_DEVICE_SIZE                 26
+#define MX_OCTOSPI2_MEMORY_TYPE                 HAL_OSPI_MEMTYPE_MACRONIX
+
+
 /*------------------------------ SPI2           -----------------------------*/
 #define MX_SPI2                                 1
 